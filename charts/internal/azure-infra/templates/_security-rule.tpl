@@ -0,0 +1,23 @@
+{{- define "security-rule" -}}
+resource "azurerm_network_security_rule" "{{ .resourceName }}" {
+  name                                       = "{{ .rule.name }}"
+  priority                                   = {{ .rule.priority }}
+  direction                                  = "{{ .rule.direction }}"
+  access                                     = "{{ .rule.access }}"
+  protocol                                   = "{{ .rule.protocol }}"
+  source_port_range                          = "{{ .rule.sourcePortRange }}"
+  destination_port_range                     = "{{ .rule.destinationPortRange }}"
+  {{- if hasKey .rule "sourceApplicationSecurityGroups" }}
+  source_application_security_group_ids      = [{{range $index, $asg := .rule.sourceApplicationSecurityGroups}}{{if $index}},{{end}}"${azurerm_application_security_group.{{$asg}}.id}"{{end}}]
+  {{- else }}
+  source_address_prefix                      = "{{ .rule.sourceAddressPrefix }}"
+  {{- end }}
+  {{- if hasKey .rule "destinationApplicationSecurityGroups" }}
+  destination_application_security_group_ids = [{{range $index, $asg := .rule.destinationApplicationSecurityGroups}}{{if $index}},{{end}}"${azurerm_application_security_group.{{$asg}}.id}"{{end}}]
+  {{- else }}
+  destination_address_prefix                 = "{{ .rule.destinationAddressPrefix }}"
+  {{- end }}
+  resource_group_name                        = "${azurerm_network_security_group.{{ .securityGroup }}.resource_group_name}"
+  network_security_group_name                = "${azurerm_network_security_group.{{ .securityGroup }}.name}"
+}
+{{- end -}}
@@ -0,0 +1,11 @@
+{{- define "subnet-associations" -}}
+resource "azurerm_subnet_route_table_association" "{{ .subnet }}" {
+  subnet_id      = "${azurerm_subnet.{{ .subnet }}.id}"
+  route_table_id = "${azurerm_route_table.workers.id}"
+}
+
+resource "azurerm_subnet_network_security_group_association" "{{ .subnet }}" {
+  subnet_id                 = "${azurerm_subnet.{{ .subnet }}.id}"
+  network_security_group_id = "${azurerm_network_security_group.{{ .securityGroup }}.id}"
+}
+{{- end -}}
@@ -3,6 +3,7 @@ provider "azurerm" {
   tenant_id       = "{{ required "azure.tenantID is required" .Values.azure.tenantID }}"
   client_id       = "${var.CLIENT_ID}"
   client_secret   = "${var.CLIENT_SECRET}"
+  features {}
 }
 
 {{ if .Values.create.resourceGroup -}}
@@ -29,7 +30,10 @@ resource "azurerm_virtual_network" "vnet" {
   resource_group_name = "${data.azurerm_resource_group.rg.name}"
   {{- end}}
   location            = "{{ required "azure.region is required" .Values.azure.region }}"
-  address_space       = ["{{ required "resourceGroup.vnet.cidr is required" .Values.resourceGroup.vnet.cidr }}"]
+  address_space       = ["{{ required "resourceGroup.vnet.cidr is required" .Values.resourceGroup.vnet.cidr }}"{{ if .Values.resourceGroup.vnet.ipv6CIDR }},"{{ .Values.resourceGroup.vnet.ipv6CIDR }}"{{ end }}]
+  {{- if .Values.resourceGroup.vnet.dnsServers }}
+  dns_servers         = [{{range $index, $dnsServer := .Values.resourceGroup.vnet.dnsServers}}{{if $index}},{{end}}"{{$dnsServer}}"{{end}}]
+  {{- end }}
 }
 {{- else -}}
 data "azurerm_virtual_network" "vnet" {
@@ -38,21 +42,110 @@ data "azurerm_virtual_network" "vnet" {
 }
 {{- end }}
 
+{{ if .Values.networks.zones -}}
+{{ range $zone := .Values.networks.zones -}}
+resource "azurerm_subnet" "workers-z{{ $zone.name }}" {
+  name                 = "{{ required "clusterName is required" $.Values.clusterName }}-nodes-z{{ $zone.name }}"
+  {{ if $.Values.create.vnet -}}
+  virtual_network_name = "${azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${azurerm_virtual_network.vnet.resource_group_name}"
+  {{- else -}}
+  virtual_network_name = "${data.azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${data.azurerm_virtual_network.vnet.resource_group_name}"
+  {{- end }}
+  address_prefix       = "{{ required "networks.zones[].cidr is required" $zone.cidr }}"
+  service_endpoints    = [{{range $index, $serviceEndpoint := $.Values.resourceGroup.subnet.serviceEndpoints}}{{if $index}},{{end}}"{{$serviceEndpoint}}"{{end}}]
+}
+
+{{ include "subnet-associations" (dict "subnet" (printf "workers-z%v" $zone.name) "securityGroup" "workers") }}
+
+{{ end -}}
+{{- else -}}
 resource "azurerm_subnet" "workers" {
-  name                      = "{{ required "clusterName is required" .Values.clusterName }}-nodes"
+  name                 = "{{ required "clusterName is required" .Values.clusterName }}-nodes"
   {{ if .Values.create.vnet -}}
-  virtual_network_name      = "${azurerm_virtual_network.vnet.name}"
-  resource_group_name       = "${azurerm_virtual_network.vnet.resource_group_name}"
+  virtual_network_name = "${azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${azurerm_virtual_network.vnet.resource_group_name}"
+  {{- else -}}
+  virtual_network_name = "${data.azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${data.azurerm_virtual_network.vnet.resource_group_name}"
+  {{- end }}
+  {{- if .Values.networks.workerIPv6 }}
+  address_prefixes     = ["{{ required "networks.worker is required" .Values.networks.worker }}","{{ .Values.networks.workerIPv6 }}"]
+  {{- else }}
+  address_prefix       = "{{ required "networks.worker is required" .Values.networks.worker }}"
+  {{- end }}
+  service_endpoints    = [{{range $index, $serviceEndpoint := .Values.resourceGroup.subnet.serviceEndpoints}}{{if $index}},{{end}}"{{$serviceEndpoint}}"{{end}}]
+}
+
+{{ include "subnet-associations" (dict "subnet" "workers" "securityGroup" "workers") }}
+{{- end }}
+
+{{ range $subnet := .Values.subnets -}}
+resource "azurerm_subnet" "subnet-{{ $subnet.name }}" {
+  name                 = "{{ required "clusterName is required" $.Values.clusterName }}-{{ $subnet.name }}"
+  {{ if $.Values.create.vnet -}}
+  virtual_network_name = "${azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${azurerm_virtual_network.vnet.resource_group_name}"
+  {{- else -}}
+  virtual_network_name = "${data.azurerm_virtual_network.vnet.name}"
+  resource_group_name  = "${data.azurerm_virtual_network.vnet.resource_group_name}"
+  {{- end }}
+  address_prefix       = "{{ required "subnets[].cidr is required" $subnet.cidr }}"
+  service_endpoints    = [{{range $index, $serviceEndpoint := $subnet.serviceEndpoints}}{{if $index}},{{end}}"{{$serviceEndpoint}}"{{end}}]
+}
+
+{{ include "subnet-associations" (dict "subnet" (printf "subnet-%s" $subnet.name) "securityGroup" (ternary (printf "subnet-%s" $subnet.name) "workers" (hasKey $subnet "securityGroup"))) }}
+
+{{ if hasKey $subnet "securityGroup" -}}
+resource "azurerm_network_security_group" "subnet-{{ $subnet.name }}" {
+  name                = "{{ $subnet.securityGroup }}"
+  location            = "{{ required "azure.region is required" $.Values.azure.region }}"
+  {{ if $.Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end}}
+}
+
+{{ range $rule := $.Values.securityRules -}}
+{{ include "security-rule" (dict "resourceName" (printf "subnet-%s-%s" $subnet.name $rule.name) "securityGroup" (printf "subnet-%s" $subnet.name) "rule" $rule) }}
+
+{{ end -}}
+{{ end -}}
+{{ end -}}
+
+{{ range $peering := .Values.vnetPeerings -}}
+resource "azurerm_virtual_network_peering" "{{ $peering.name }}" {
+  name                         = "{{ $peering.name }}"
+  {{ if $.Values.create.vnet -}}
+  virtual_network_name         = "${azurerm_virtual_network.vnet.name}"
+  resource_group_name          = "${azurerm_virtual_network.vnet.resource_group_name}"
   {{- else -}}
-  virtual_network_name      = "${data.azurerm_virtual_network.vnet.name}"
-  resource_group_name       = "${data.azurerm_virtual_network.vnet.resource_group_name}"
+  virtual_network_name         = "${data.azurerm_virtual_network.vnet.name}"
+  resource_group_name          = "${data.azurerm_virtual_network.vnet.resource_group_name}"
   {{- end }}
-  address_prefix            = "{{ required "networks.worker is required" .Values.networks.worker }}"
-  service_endpoints         = [{{range $index, $serviceEndpoint := .Values.resourceGroup.subnet.serviceEndpoints}}{{if $index}},{{end}}"{{$serviceEndpoint}}"{{end}}]
-  route_table_id            = "${azurerm_route_table.workers.id}"
-  network_security_group_id = "${azurerm_network_security_group.workers.id}"
+  remote_virtual_network_id    = "{{ required "vnetPeerings[].remoteVNetID is required" $peering.remoteVNetID }}"
+  allow_virtual_network_access = true
+  allow_forwarded_traffic      = {{ $peering.allowForwardedTraffic }}
+  use_remote_gateways          = {{ $peering.useRemoteGateways }}
 }
 
+{{ end -}}
+{{ range $link := .Values.privateDNSZoneLinks -}}
+resource "azurerm_private_dns_zone_virtual_network_link" "{{ $link.key }}" {
+  name                  = "{{ required "clusterName is required" $.Values.clusterName }}"
+  resource_group_name   = "{{ required "privateDNSZoneLinks[].resourceGroup is required" $link.resourceGroup }}"
+  private_dns_zone_name = "{{ required "privateDNSZoneLinks[].zoneName is required" $link.zoneName }}"
+  {{ if $.Values.create.vnet -}}
+  virtual_network_id    = "${azurerm_virtual_network.vnet.id}"
+  {{- else -}}
+  virtual_network_id    = "${data.azurerm_virtual_network.vnet.id}"
+  {{- end }}
+  registration_enabled  = false
+}
+
+{{ end -}}
 resource "azurerm_route_table" "workers" {
   name                = "worker_route_table"
   location            = "{{ required "azure.region is required" .Values.azure.region }}"
@@ -63,6 +156,20 @@ resource "azurerm_route_table" "workers" {
   {{- end}}
 }
 
+{{/* Routes are managed as separate resources instead of inline route blocks, otherwise Terraform would remove the pod routes maintained by the cloud-controller-manager in the same route table. */ -}}
+{{ range $route := .Values.routes -}}
+resource "azurerm_route" "{{ $route.name }}" {
+  name                   = "{{ $route.name }}"
+  resource_group_name    = "${azurerm_route_table.workers.resource_group_name}"
+  route_table_name       = "${azurerm_route_table.workers.name}"
+  address_prefix         = "{{ required "routes[].addressPrefix is required" $route.addressPrefix }}"
+  next_hop_type          = "{{ required "routes[].nextHopType is required" $route.nextHopType }}"
+  {{- if $route.nextHopIPAddress }}
+  next_hop_in_ip_address = "{{ $route.nextHopIPAddress }}"
+  {{- end }}
+}
+
+{{ end -}}
 resource "azurerm_network_security_group" "workers" {
   name                = "{{ required "clusterName is required" .Values.clusterName }}-workers"
   location            = "{{ required "azure.region is required" .Values.azure.region }}"
@@ -73,10 +180,46 @@ resource "azurerm_network_security_group" "workers" {
   {{- end}}
 }
 
-{{ if .Values.create.availabilitySet -}}
+{{ range $rule := .Values.securityRules -}}
+{{ include "security-rule" (dict "resourceName" $rule.name "securityGroup" "workers" "rule" $rule) }}
+
+{{ end -}}
+{{ range $asg := .Values.applicationSecurityGroups -}}
+resource "azurerm_application_security_group" "{{ $asg.name }}" {
+  name                = "{{ required "clusterName is required" $.Values.clusterName }}-{{ $asg.name }}"
+  location            = "{{ required "azure.region is required" $.Values.azure.region }}"
+  {{ if $.Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end}}
+}
+
+{{ end -}}
+
+{{ if .Values.proximityPlacementGroups -}}
+#=====================================================================
+#= Proximity Placement Groups
+#=====================================================================
+
+{{ range $ppg := .Values.proximityPlacementGroups -}}
+resource "azurerm_proximity_placement_group" "{{ $ppg.key }}" {
+  name                = "{{ required "clusterName is required" $.Values.clusterName }}-{{ $ppg.key }}"
+  {{ if $.Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end}}
+  location            = "{{ required "azure.region is required" $.Values.azure.region }}"
+}
+
+{{ end -}}
+{{ end -}}
+{{ if or .Values.create.availabilitySet .Values.availabilitySet.workerPools -}}
 #=====================================================================
 #= Availability Set
 #=====================================================================
+{{- if .Values.create.availabilitySet }}
 
 resource "azurerm_availability_set" "workers" {
   name                         = "{{ required "clusterName is required" .Values.clusterName }}-avset-workers"
@@ -89,7 +232,29 @@ resource "azurerm_availability_set" "workers" {
   platform_update_domain_count = "{{ required "azure.countUpdateDomains is required" .Values.azure.countUpdateDomains }}"
   platform_fault_domain_count  = "{{ required "azure.countFaultDomains is required" .Values.azure.countFaultDomains }}"
   managed                      = true
+  {{- if .Values.availabilitySet.proximityPlacementGroup }}
+  proximity_placement_group_id = "${azurerm_proximity_placement_group.{{ .Values.availabilitySet.proximityPlacementGroup }}.id}"
+  {{- end }}
+}
+{{- end}}
+{{- range $workerPool := .Values.availabilitySet.workerPools }}
+
+resource "azurerm_availability_set" "pool-{{ $workerPool }}" {
+  name                         = "{{ required "clusterName is required" $.Values.clusterName }}-avset-{{ $workerPool }}"
+  {{ if $.Values.create.resourceGroup -}}
+  resource_group_name          = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name          = "${data.azurerm_resource_group.rg.name}"
+  {{- end}}
+  location                     = "{{ required "azure.region is required" $.Values.azure.region }}"
+  platform_update_domain_count = "{{ required "azure.countUpdateDomains is required" $.Values.azure.countUpdateDomains }}"
+  platform_fault_domain_count  = "{{ required "azure.countFaultDomains is required" $.Values.azure.countFaultDomains }}"
+  managed                      = true
+  {{- if $.Values.availabilitySet.proximityPlacementGroup }}
+  proximity_placement_group_id = "${azurerm_proximity_placement_group.{{ $.Values.availabilitySet.proximityPlacementGroup }}.id}"
+  {{- end }}
 }
+{{- end }}
 {{- end}}
 
 //=====================================================================
@@ -118,9 +283,18 @@ output "{{ .Values.outputKeys.vnetResourceGroup }}" {
 }
 {{- end}}
 
+{{ if .Values.networks.zones -}}
+{{ range $zone := .Values.networks.zones -}}
+output "{{ $.Values.outputKeys.zoneSubnetNamePrefix }}{{ $zone.name }}" {
+  value = "${azurerm_subnet.workers-z{{ $zone.name }}.name}"
+}
+
+{{ end -}}
+{{- else -}}
 output "{{ .Values.outputKeys.subnetName }}" {
   value = "${azurerm_subnet.workers.name}"
 }
+{{- end }}
 
 output "{{ .Values.outputKeys.routeTableName }}" {
   value = "${azurerm_route_table.workers.name}"
@@ -130,6 +304,43 @@ output "{{ .Values.outputKeys.securityGroupName }}" {
   value = "${azurerm_network_security_group.workers.name}"
 }
 
+{{ range $peering := .Values.vnetPeerings -}}
+output "{{ $.Values.outputKeys.vnetPeeringIDPrefix }}{{ $peering.name }}" {
+  value = "${azurerm_virtual_network_peering.{{ $peering.name }}.id}"
+}
+
+{{ end -}}
+{{ range $link := .Values.privateDNSZoneLinks -}}
+output "{{ $.Values.outputKeys.privateDNSZoneLinkIDPrefix }}{{ $link.key }}" {
+  value = "${azurerm_private_dns_zone_virtual_network_link.{{ $link.key }}.id}"
+}
+
+{{ end -}}
+{{ range $subnet := .Values.subnets -}}
+output "{{ $.Values.outputKeys.subnetNamePrefix }}{{ $subnet.name }}" {
+  value = "${azurerm_subnet.subnet-{{ $subnet.name }}.name}"
+}
+
+{{ if hasKey $subnet "securityGroup" -}}
+output "{{ $.Values.outputKeys.securityGroupNamePrefix }}{{ $subnet.name }}" {
+  value = "${azurerm_network_security_group.subnet-{{ $subnet.name }}.name}"
+}
+
+{{ end -}}
+{{ end -}}
+{{ range $asg := .Values.applicationSecurityGroups -}}
+output "{{ $.Values.outputKeys.applicationSecurityGroupIDPrefix }}{{ $asg.name }}" {
+  value = "${azurerm_application_security_group.{{ $asg.name }}.id}"
+}
+
+{{ end -}}
+
+{{ range $ppg := .Values.proximityPlacementGroups -}}
+output "{{ $.Values.outputKeys.proximityPlacementGroupIDPrefix }}{{ $ppg.key }}" {
+  value = "${azurerm_proximity_placement_group.{{ $ppg.key }}.id}"
+}
+
+{{ end -}}
 {{ if .Values.create.availabilitySet -}}
 output "{{ .Values.outputKeys.availabilitySetID }}" {
   value = "${azurerm_availability_set.workers.id}"
@@ -138,4 +349,20 @@ output "{{ .Values.outputKeys.availabilitySetID }}" {
 output "{{ .Values.outputKeys.availabilitySetName }}" {
   value = "${azurerm_availability_set.workers.name}"
 }
-{{- end}}
\ No newline at end of file
+{{- end}}
+{{- if hasKey .Values.availabilitySet "workerPools" }}
+
+output "{{ .Values.outputKeys.availabilitySetWorkerPools }}" {
+  value = "{{ join "," .Values.availabilitySet.workerPools }}"
+}
+{{- end }}
+{{- range $workerPool := .Values.availabilitySet.workerPools }}
+
+output "{{ $.Values.outputKeys.availabilitySetIDPrefix }}{{ $workerPool }}" {
+  value = "${azurerm_availability_set.pool-{{ $workerPool }}.id}"
+}
+
+output "{{ $.Values.outputKeys.availabilitySetNamePrefix }}{{ $workerPool }}" {
+  value = "${azurerm_availability_set.pool-{{ $workerPool }}.name}"
+}
+{{- end }}
\ No newline at end of file
@@ -11,20 +11,22 @@ vnetResourceGroup: "{{ .Values.vnetResourceGroup }}"
 {{- end }}
 {{- if hasKey .Values "availabilitySetName" }}
 primaryAvailabilitySetName: "{{ .Values.availabilitySetName }}"
+{{- end }}
+{{- if and (hasKey .Values "availabilitySetName") (not .Values.dualStack) }}
 loadBalancerSku: "basic"
 {{- else }}
 loadBalancerSku: "standard"
 {{- end }}
-cloudProviderBackoff: true
-cloudProviderBackoffRetries: 6
-cloudProviderBackoffExponent: 1.5
-cloudProviderBackoffDuration: 5
-cloudProviderBackoffJitter: 1.0
-cloudProviderRateLimit: true
-cloudProviderRateLimitQPS: {{ ( max .Values.maxNodes 10 ) }}
-cloudProviderRateLimitBucket: 100
-cloudProviderRateLimitQPSWrite: {{ ( max .Values.maxNodes 10 ) }}
-cloudProviderRateLimitBucketWrite: 100
+cloudProviderBackoff: {{ .Values.backoff.enabled }}
+cloudProviderBackoffRetries: {{ .Values.backoff.retries }}
+cloudProviderBackoffExponent: {{ .Values.backoff.exponent }}
+cloudProviderBackoffDuration: {{ .Values.backoff.duration }}
+cloudProviderBackoffJitter: {{ .Values.backoff.jitter }}
+cloudProviderRateLimit: {{ .Values.rateLimit.enabled }}
+cloudProviderRateLimitQPS: {{ .Values.rateLimit.qps }}
+cloudProviderRateLimitBucket: {{ .Values.rateLimit.bucket }}
+cloudProviderRateLimitQPSWrite: {{ .Values.rateLimit.qpsWrite }}
+cloudProviderRateLimitBucketWrite: {{ .Values.rateLimit.bucketWrite }}
 {{- if semverCompare ">= 1.14" .Values.kubernetesVersion }}
 cloudProviderBackoffMode: v2
 {{- end }}
@@ -5,3 +5,11 @@ storage.k8s.io/v1
 storage.k8s.io/v1beta1
 {{- end -}}
 {{- end -}}
+
+{{- define "storageclass.diskprovisioner" -}}
+{{- if .Values.useCSI -}}
+disk.csi.azure.com
+{{- else -}}
+kubernetes.io/azure-disk
+{{- end -}}
+{{- end -}}
@@ -28,6 +28,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-azure/pkg/controller/healthcheck"
 	azureinfrastructure "github.com/gardener/gardener-extension-provider-azure/pkg/controller/infrastructure"
 	azureworker "github.com/gardener/gardener-extension-provider-azure/pkg/controller/worker"
+	azurecontrolplanewebhook "github.com/gardener/gardener-extension-provider-azure/pkg/webhook/controlplane"
 	azurecontrolplanebackup "github.com/gardener/gardener-extension-provider-azure/pkg/webhook/controlplanebackup"
 	azurecontrolplaneexposure "github.com/gardener/gardener-extension-provider-azure/pkg/webhook/controlplaneexposure"
 
@@ -153,7 +154,11 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			)
 
 			configFileOpts.Completed().ApplyETCDStorage(&azurecontrolplaneexposure.DefaultAddOptions.ETCDStorage)
+			configFileOpts.Completed().ApplyKubeAPIServerExposure(&azurecontrolplaneexposure.DefaultAddOptions.KubeAPIServerExposure)
+			configFileOpts.Completed().ApplyKubeAPIServerService(&azurecontrolplaneexposure.DefaultAddOptions.KubeAPIServerService)
+			configFileOpts.Completed().ApplyKubeAPIServerExposure(&azurecontrolplane.DefaultAddOptions.KubeAPIServerExposure)
 			configFileOpts.Completed().ApplyETCDBackup(&azurecontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyACRCredentialProvider(&azurecontrolplanewebhook.DefaultAddOptions.ACRCredentialProvider)
 			configFileOpts.Completed().ApplyHealthCheckConfig(&healthcheck.DefaultAddOptions.HealthCheckConfig)
 			healthCheckCtrlOpts.Completed().Apply(&healthcheck.DefaultAddOptions.Controller)
 			backupBucketCtrlOpts.Completed().Apply(&azurebackupbucket.DefaultAddOptions.Controller)
@@ -32,6 +32,30 @@ func FindSubnetByPurpose(subnets []api.Subnet, purpose api.Purpose) (*api.Subnet
 	return nil, fmt.Errorf("cannot find subnet with purpose %q", purpose)
 }
 
+// FindSubnetByName takes a list of subnets and tries to find the first entry
+// whose name matches with the given name. If no such entry is found then an error will be
+// returned.
+func FindSubnetByName(subnets []api.Subnet, name string) (*api.Subnet, error) {
+	for _, subnet := range subnets {
+		if subnet.Name == name {
+			return &subnet, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find subnet with name %q", name)
+}
+
+// FindSubnetByZone takes a list of subnets and tries to find the first entry
+// which was created for the given zone. If no such entry is found then an error will be
+// returned.
+func FindSubnetByZone(subnets []api.Subnet, zone string) (*api.Subnet, error) {
+	for _, subnet := range subnets {
+		if subnet.Zone != nil && *subnet.Zone == zone {
+			return &subnet, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find subnet for zone %q", zone)
+}
+
 // FindSecurityGroupByPurpose takes a list of security groups and tries to find the first entry
 // whose purpose matches with the given purpose. If no such entry is found then an error will be
 // returned.
@@ -68,6 +92,56 @@ func FindAvailabilitySetByPurpose(availabilitySets []api.AvailabilitySet, purpos
 	return nil, fmt.Errorf("cannot find availability set with purpose %q", purpose)
 }
 
+// FindAvailabilitySetByWorkerPool takes a list of availability sets and tries to find the entry of the given worker
+// pool. If the availability sets are not created per worker pool then the shared availability set with the given
+// purpose is returned. If no such entry is found then an error will be returned.
+func FindAvailabilitySetByWorkerPool(availabilitySets []api.AvailabilitySet, purpose api.Purpose, workerPool string) (*api.AvailabilitySet, error) {
+	perWorkerPool := false
+	for _, availabilitySet := range availabilitySets {
+		if availabilitySet.Purpose != purpose || availabilitySet.WorkerPool == nil {
+			continue
+		}
+		if *availabilitySet.WorkerPool == workerPool {
+			return &availabilitySet, nil
+		}
+		perWorkerPool = true
+	}
+	if perWorkerPool {
+		return nil, fmt.Errorf("cannot find availability set with purpose %q for worker pool %q", purpose, workerPool)
+	}
+	return FindAvailabilitySetByPurpose(availabilitySets, purpose)
+}
+
+// FindApplicationSecurityGroupByName takes a list of application security groups and tries to find the first entry
+// whose name matches with the given name. If no such entry is found then an error will be
+// returned.
+func FindApplicationSecurityGroupByName(applicationSecurityGroups []api.ApplicationSecurityGroupStatus, name string) (*api.ApplicationSecurityGroupStatus, error) {
+	for _, applicationSecurityGroup := range applicationSecurityGroups {
+		if applicationSecurityGroup.Name == name {
+			return &applicationSecurityGroup, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find application security group with name %q", name)
+}
+
+// FindProximityPlacementGroup takes a list of proximity placement groups and tries to find the first entry
+// whose name and zone match with the given name and zone. The zone must be nil for non-zoned clusters. If no such
+// entry is found then an error will be returned.
+func FindProximityPlacementGroup(proximityPlacementGroups []api.ProximityPlacementGroupStatus, name string, zone *string) (*api.ProximityPlacementGroupStatus, error) {
+	for _, proximityPlacementGroup := range proximityPlacementGroups {
+		if proximityPlacementGroup.Name != name {
+			continue
+		}
+		if (zone == nil && proximityPlacementGroup.Zone == nil) || (zone != nil && proximityPlacementGroup.Zone != nil && *zone == *proximityPlacementGroup.Zone) {
+			return &proximityPlacementGroup, nil
+		}
+	}
+	if zone != nil {
+		return nil, fmt.Errorf("cannot find proximity placement group with name %q for zone %q", name, *zone)
+	}
+	return nil, fmt.Errorf("cannot find proximity placement group with name %q", name)
+}
+
 // FindMachineImage takes a list of machine images and tries to find the first entry
 // whose name, version, and zone matches with the given name, version, and zone. If no such entry is
 // found then an error will be returned.
@@ -32,6 +32,11 @@ var _ = Describe("Helper", func() {
 		purpose      api.Purpose = "foo"
 		purposeWrong api.Purpose = "baz"
 		urn          string      = "publisher:offer:sku:version"
+		zone         string      = "1"
+		zoneWrong    string      = "2"
+
+		workerPool      = "pool"
+		workerPoolWrong = "other"
 	)
 
 	DescribeTable("#FindSubnetByPurpose",
@@ -46,6 +51,31 @@ var _ = Describe("Helper", func() {
 		Entry("entry exists", []api.Subnet{{Name: "bar", Purpose: purpose}}, purpose, &api.Subnet{Name: "bar", Purpose: purpose}, false),
 	)
 
+	DescribeTable("#FindSubnetByName",
+		func(subnets []api.Subnet, name string, expectedSubnet *api.Subnet, expectErr bool) {
+			subnet, err := FindSubnetByName(subnets, name)
+			expectResults(subnet, expectedSubnet, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, true),
+		Entry("empty list", []api.Subnet{}, "foo", nil, true),
+		Entry("entry not found", []api.Subnet{{Name: "bar", Purpose: purpose}}, "foo", nil, true),
+		Entry("entry exists", []api.Subnet{{Name: "bar", Purpose: purpose}}, "bar", &api.Subnet{Name: "bar", Purpose: purpose}, false),
+	)
+
+	DescribeTable("#FindSubnetByZone",
+		func(subnets []api.Subnet, zone string, expectedSubnet *api.Subnet, expectErr bool) {
+			subnet, err := FindSubnetByZone(subnets, zone)
+			expectResults(subnet, expectedSubnet, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "1", nil, true),
+		Entry("empty list", []api.Subnet{}, "1", nil, true),
+		Entry("entry without zone", []api.Subnet{{Name: "bar", Purpose: purpose}}, "1", nil, true),
+		Entry("entry not found", []api.Subnet{{Name: "bar", Purpose: purpose, Zone: &zoneWrong}}, "1", nil, true),
+		Entry("entry exists", []api.Subnet{{Name: "bar", Purpose: purpose, Zone: &zone}}, "1", &api.Subnet{Name: "bar", Purpose: purpose, Zone: &zone}, false),
+	)
+
 	DescribeTable("#FindSecurityGroupByPurpose",
 		func(securityGroups []api.SecurityGroup, purpose api.Purpose, expectedSecurityGroup *api.SecurityGroup, expectErr bool) {
 			securityGroup, err := FindSecurityGroupByPurpose(securityGroups, purpose)
@@ -82,6 +112,46 @@ var _ = Describe("Helper", func() {
 		Entry("entry exists", []api.AvailabilitySet{{ID: "bar", Purpose: purpose}}, purpose, &api.AvailabilitySet{ID: "bar", Purpose: purpose}, false),
 	)
 
+	DescribeTable("#FindAvailabilitySetByWorkerPool",
+		func(availabilitySets []api.AvailabilitySet, workerPool string, expectedAvailabilitySet *api.AvailabilitySet, expectErr bool) {
+			availabilitySet, err := FindAvailabilitySetByWorkerPool(availabilitySets, purpose, workerPool)
+			expectResults(availabilitySet, expectedAvailabilitySet, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, true),
+		Entry("empty list", []api.AvailabilitySet{}, "foo", nil, true),
+		Entry("entry not found", []api.AvailabilitySet{{ID: "bar", Purpose: purpose, WorkerPool: &workerPoolWrong}}, "foo", nil, true),
+		Entry("entry exists", []api.AvailabilitySet{{ID: "bar", Purpose: purpose, WorkerPool: &workerPoolWrong}, {ID: "baz", Purpose: purpose, WorkerPool: &workerPool}}, workerPool, &api.AvailabilitySet{ID: "baz", Purpose: purpose, WorkerPool: &workerPool}, false),
+		Entry("shared entry exists", []api.AvailabilitySet{{ID: "bar", Purpose: purpose}}, "foo", &api.AvailabilitySet{ID: "bar", Purpose: purpose}, false),
+	)
+
+	DescribeTable("#FindApplicationSecurityGroupByName",
+		func(applicationSecurityGroups []api.ApplicationSecurityGroupStatus, name string, expectedApplicationSecurityGroup *api.ApplicationSecurityGroupStatus, expectErr bool) {
+			applicationSecurityGroup, err := FindApplicationSecurityGroupByName(applicationSecurityGroups, name)
+			expectResults(applicationSecurityGroup, expectedApplicationSecurityGroup, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, true),
+		Entry("empty list", []api.ApplicationSecurityGroupStatus{}, "foo", nil, true),
+		Entry("entry not found", []api.ApplicationSecurityGroupStatus{{Name: "bar", ID: "id"}}, "foo", nil, true),
+		Entry("entry exists", []api.ApplicationSecurityGroupStatus{{Name: "bar", ID: "id"}}, "bar", &api.ApplicationSecurityGroupStatus{Name: "bar", ID: "id"}, false),
+	)
+
+	DescribeTable("#FindProximityPlacementGroup",
+		func(proximityPlacementGroups []api.ProximityPlacementGroupStatus, name string, zone *string, expectedProximityPlacementGroup *api.ProximityPlacementGroupStatus, expectErr bool) {
+			proximityPlacementGroup, err := FindProximityPlacementGroup(proximityPlacementGroups, name, zone)
+			expectResults(proximityPlacementGroup, expectedProximityPlacementGroup, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, nil, true),
+		Entry("empty list", []api.ProximityPlacementGroupStatus{}, "foo", nil, nil, true),
+		Entry("entry not found (no name)", []api.ProximityPlacementGroupStatus{{Name: "bar", ID: "id"}}, "foo", nil, nil, true),
+		Entry("entry not found (no zone)", []api.ProximityPlacementGroupStatus{{Name: "bar", ID: "id", Zone: &zoneWrong}}, "bar", &zone, nil, true),
+		Entry("entry not found (zoned entry)", []api.ProximityPlacementGroupStatus{{Name: "bar", ID: "id", Zone: &zone}}, "bar", nil, nil, true),
+		Entry("entry exists", []api.ProximityPlacementGroupStatus{{Name: "bar", ID: "id"}}, "bar", nil, &api.ProximityPlacementGroupStatus{Name: "bar", ID: "id"}, false),
+		Entry("entry exists (zone)", []api.ProximityPlacementGroupStatus{{Name: "bar", ID: "id1", Zone: &zoneWrong}, {Name: "bar", ID: "id2", Zone: &zone}}, "bar", &zone, &api.ProximityPlacementGroupStatus{Name: "bar", ID: "id2", Zone: &zone}, false),
+	)
+
 	DescribeTable("#FindMachineImage",
 		func(machineImages []api.MachineImage, name, version string, expectedMachineImage *api.MachineImage, expectErr bool) {
 			machineImage, err := FindMachineImage(machineImages, name, version)
@@ -61,6 +61,19 @@ func InfrastructureConfigFromInfrastructure(infra *extensionsv1alpha1.Infrastruc
 	return nil, fmt.Errorf("provider config is not set on the infrastructure resource")
 }
 
+// InfrastructureStatusFromInfrastructure extracts the InfrastructureStatus from the
+// ProviderStatus section of the given Infrastructure. It returns nil if the status is not yet set.
+func InfrastructureStatusFromInfrastructure(infra *extensionsv1alpha1.Infrastructure) (*api.InfrastructureStatus, error) {
+	if infra.Status.ProviderStatus == nil || infra.Status.ProviderStatus.Raw == nil {
+		return nil, nil
+	}
+	status := &api.InfrastructureStatus{}
+	if _, _, err := decoder.Decode(infra.Status.ProviderStatus.Raw, nil, status); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerStatus of infrastructure '%s'", util.ObjectName(infra))
+	}
+	return status, nil
+}
+
 // CloudProfileConfigFromCluster decodes the provider specific cloud profile configuration for a cluster
 func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfileConfig, error) {
 	var cloudProfileConfig *api.CloudProfileConfig
@@ -72,3 +85,28 @@ func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfi
 	}
 	return cloudProfileConfig, nil
 }
+
+// ControlPlaneConfigFromCluster decodes the provider specific control plane configuration of the shoot of a cluster.
+// It returns an empty configuration if the shoot does not specify one.
+func ControlPlaneConfigFromCluster(cluster *controller.Cluster) (*api.ControlPlaneConfig, error) {
+	controlPlaneConfig := &api.ControlPlaneConfig{}
+	if cluster != nil && cluster.Shoot != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig.Raw != nil {
+		if _, _, err := decoder.Decode(cluster.Shoot.Spec.Provider.ControlPlaneConfig.Raw, nil, controlPlaneConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode controlPlaneConfig of shoot '%s'", util.ObjectName(cluster.Shoot))
+		}
+	}
+	return controlPlaneConfig, nil
+}
+
+// InfrastructureConfigFromCluster decodes the provider specific infrastructure configuration of the shoot of a cluster.
+// It returns nil if the shoot does not specify one.
+func InfrastructureConfigFromCluster(cluster *controller.Cluster) (*api.InfrastructureConfig, error) {
+	var infrastructureConfig *api.InfrastructureConfig
+	if cluster != nil && cluster.Shoot != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw != nil {
+		infrastructureConfig = &api.InfrastructureConfig{}
+		if _, _, err := decoder.Decode(cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw, nil, infrastructureConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode infrastructureConfig of shoot '%s'", util.ObjectName(cluster.Shoot))
+		}
+	}
+	return infrastructureConfig, nil
+}
@@ -49,6 +49,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&InfrastructureConfig{},
 		&InfrastructureStatus{},
 		&ControlPlaneConfig{},
+		&ControlPlaneStatus{},
+		&WorkerConfig{},
 		&WorkerStatus{},
 	)
 	return nil
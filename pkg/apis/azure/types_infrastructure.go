@@ -29,6 +29,21 @@ type InfrastructureConfig struct {
 	Networks NetworkConfig
 	// Zoned indicates whether the cluster uses zones
 	Zoned bool
+	// ProximityPlacementGroups is a list of proximity placement groups which should be created. Worker pools can
+	// reference them in their provider config to co-locate their machines.
+	ProximityPlacementGroups []ProximityPlacementGroup
+	// AvailabilitySetPerWorkerPool indicates whether one availability set per worker pool shall be created for a non
+	// zoned cluster instead of a single availability set shared by all worker pools.
+	AvailabilitySetPerWorkerPool bool
+}
+
+// ProximityPlacementGroup is a proximity placement group which should be created.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group.
+	Name string
+	// Zone is the zone the proximity placement group is created for. It must be set for zoned clusters, a group
+	// of the same name can be declared once per zone. It must not be set for non-zoned clusters.
+	Zone *string
 }
 
 // ResourceGroup is azure resource group
@@ -42,11 +57,142 @@ type NetworkConfig struct {
 	// VNet indicates whether to use an existing VNet or create a new one.
 	VNet VNet
 	// Workers is the worker subnet range to create (used for the VMs).
+	// It must not be set if Zones is specified.
 	Workers string
+	// IPv6Workers is the IPv6 range of the worker subnet for dual-stack clusters. If set, the worker subnet and the VMs
+	// get an IPv6 address prefix and address in addition to the IPv4 one. It must not be set if Zones is specified.
+	IPv6Workers *string
 	// ServiceEndpoints is a list of Azure ServiceEndpoints which should be associated with the worker subnet.
 	ServiceEndpoints []string
+	// ApplicationSecurityGroups is a list of application security groups which should be created.
+	ApplicationSecurityGroups []ApplicationSecurityGroup
+	// SecurityRules is a list of additional rules which should be added to the worker network security group.
+	SecurityRules []SecurityRule
+	// Subnets is a list of additional worker subnets which should be created. Worker pools can select one of them
+	// in their provider config.
+	Subnets []WorkerSubnet
+	// Zones is a list of zones with one worker subnet per zone. It can only be used for zoned clusters and is mutually
+	// exclusive with Workers.
+	Zones []Zone
+	// Routes is a list of user-defined routes which should be added to the worker route table.
+	Routes []Route
+}
+
+// Route is a user-defined route of the worker route table.
+type Route struct {
+	// Name is the name of the route.
+	Name string
+	// AddressPrefix is the destination CIDR the route applies to.
+	AddressPrefix string
+	// NextHopType is the type of the hop the traffic is sent to.
+	NextHopType RouteNextHopType
+	// NextHopIPAddress is the IP address the traffic is forwarded to. It must only be set if the next hop type is VirtualAppliance.
+	NextHopIPAddress *string
+}
+
+// RouteNextHopType is the type of the next hop of a route.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway forwards the traffic to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway RouteNextHopType = "VirtualNetworkGateway"
+	// RouteNextHopTypeVnetLocal keeps the traffic within the virtual network.
+	RouteNextHopTypeVnetLocal RouteNextHopType = "VnetLocal"
+	// RouteNextHopTypeInternet forwards the traffic to the internet.
+	RouteNextHopTypeInternet RouteNextHopType = "Internet"
+	// RouteNextHopTypeVirtualAppliance forwards the traffic to a virtual appliance, e.g. a firewall.
+	RouteNextHopTypeVirtualAppliance RouteNextHopType = "VirtualAppliance"
+	// RouteNextHopTypeNone drops the traffic.
+	RouteNextHopTypeNone RouteNextHopType = "None"
+)
+
+// Zone describes the worker subnet of an availability zone.
+type Zone struct {
+	// Name is the name of the zone.
+	Name string
+	// CIDR is the CIDR of the worker subnet of the zone.
+	CIDR string
+}
+
+// WorkerSubnet is an additional worker subnet which should be created.
+type WorkerSubnet struct {
+	// Name is the name of the subnet.
+	Name string
+	// CIDR is the CIDR of the subnet. It must be contained in the VNet CIDR.
+	CIDR string
+	// ServiceEndpoints is a list of Azure ServiceEndpoints which should be associated with the subnet.
+	ServiceEndpoints []string
+	// SecurityGroup is the name of a dedicated network security group which should be created for the subnet.
+	// If not set, the subnet is associated with the worker network security group.
+	SecurityGroup *string
+}
+
+// ApplicationSecurityGroup is an application security group which should be created.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string
 }
 
+// SecurityRule is a rule of the worker network security group.
+type SecurityRule struct {
+	// Name is the name of the rule.
+	Name string
+	// Priority is the priority of the rule. Rules with lower values are evaluated first.
+	Priority int32
+	// Direction is the direction of the traffic the rule applies to.
+	Direction SecurityRuleDirection
+	// Access specifies whether matching traffic is allowed or denied.
+	Access SecurityRuleAccess
+	// Protocol is the network protocol the rule applies to.
+	Protocol SecurityRuleProtocol
+	// SourceAddressPrefix is the CIDR or source IP range the rule applies to.
+	SourceAddressPrefix *string
+	// SourcePortRange is the source port or port range the rule applies to.
+	SourcePortRange *string
+	// SourceApplicationSecurityGroups is a list of names of application security groups which are the source of the rule.
+	SourceApplicationSecurityGroups []string
+	// DestinationAddressPrefix is the CIDR or destination IP range the rule applies to.
+	DestinationAddressPrefix *string
+	// DestinationPortRange is the destination port or port range the rule applies to.
+	DestinationPortRange *string
+	// DestinationApplicationSecurityGroups is a list of names of application security groups which are the destination of the rule.
+	DestinationApplicationSecurityGroups []string
+}
+
+// SecurityRuleDirection is the direction of a security rule.
+type SecurityRuleDirection string
+
+const (
+	// SecurityRuleDirectionInbound is the direction for incoming traffic.
+	SecurityRuleDirectionInbound SecurityRuleDirection = "Inbound"
+	// SecurityRuleDirectionOutbound is the direction for outgoing traffic.
+	SecurityRuleDirectionOutbound SecurityRuleDirection = "Outbound"
+)
+
+// SecurityRuleAccess specifies whether a security rule allows or denies traffic.
+type SecurityRuleAccess string
+
+const (
+	// SecurityRuleAccessAllow allows matching traffic.
+	SecurityRuleAccessAllow SecurityRuleAccess = "Allow"
+	// SecurityRuleAccessDeny denies matching traffic.
+	SecurityRuleAccessDeny SecurityRuleAccess = "Deny"
+)
+
+// SecurityRuleProtocol is the network protocol of a security rule.
+type SecurityRuleProtocol string
+
+const (
+	// SecurityRuleProtocolTCP is the TCP protocol.
+	SecurityRuleProtocolTCP SecurityRuleProtocol = "Tcp"
+	// SecurityRuleProtocolUDP is the UDP protocol.
+	SecurityRuleProtocolUDP SecurityRuleProtocol = "Udp"
+	// SecurityRuleProtocolICMP is the ICMP protocol.
+	SecurityRuleProtocolICMP SecurityRuleProtocol = "Icmp"
+	// SecurityRuleProtocolAll matches all protocols.
+	SecurityRuleProtocolAll SecurityRuleProtocol = "*"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // InfrastructureStatus contains information about created infrastructure resources.
@@ -62,8 +208,24 @@ type InfrastructureStatus struct {
 	RouteTables []RouteTable
 	// SecurityGroups is a list of created security groups
 	SecurityGroups []SecurityGroup
+	// ApplicationSecurityGroups is a list of created application security groups
+	ApplicationSecurityGroups []ApplicationSecurityGroupStatus
 	// Zoned indicates whether the cluster uses zones
 	Zoned bool
+	// ProximityPlacementGroups is a list of created proximity placement groups
+	ProximityPlacementGroups []ProximityPlacementGroupStatus
+}
+
+// ProximityPlacementGroupStatus contains information about a created proximity placement group.
+type ProximityPlacementGroupStatus struct {
+	// Name is the name of the proximity placement group as declared in the InfrastructureConfig.
+	Name string
+	// ID is the id of the proximity placement group.
+	ID string
+	// Purpose is the purpose of the proximity placement group.
+	Purpose Purpose
+	// Zone is the zone the proximity placement group was created for, if any.
+	Zone *string
 }
 
 // NetworkStatus is the current status of the infrastructure networks.
@@ -90,6 +252,10 @@ type Subnet struct {
 	Name string
 	// Purpose is the purpose for which the subnet was created.
 	Purpose Purpose
+	// Zone is the name of the zone the subnet was created for, if any.
+	Zone *string
+	// DualStack indicates whether the subnet has an IPv6 address prefix in addition to the IPv4 one.
+	DualStack bool
 }
 
 // AvailabilitySet contains information about the azure availability set
@@ -100,6 +266,9 @@ type AvailabilitySet struct {
 	ID string
 	// Name is the name of the availability set
 	Name string
+	// WorkerPool is the name of the worker pool the availability set belongs to. It is not set if the availability
+	// set is shared by all worker pools.
+	WorkerPool *string
 }
 
 // RouteTable is the azure route table
@@ -118,6 +287,14 @@ type SecurityGroup struct {
 	Name string
 }
 
+// ApplicationSecurityGroupStatus contains information about a created application security group
+type ApplicationSecurityGroupStatus struct {
+	// Name is the name of the application security group
+	Name string
+	// ID is the id of the application security group
+	ID string
+}
+
 // VNet contains information about the VNet and some related resources.
 type VNet struct {
 	// Name is the VNet name.
@@ -126,6 +303,31 @@ type VNet struct {
 	ResourceGroup *string
 	// CIDR is the VNet CIDR
 	CIDR *string
+	// IPv6CIDR is the IPv6 address space of the VNet for dual-stack clusters. If not set, the IPv6 worker range is used.
+	IPv6CIDR *string
+	// Peerings is a list of peerings from the VNet to remote VNets, e.g. to a central hub VNet.
+	Peerings []VNetPeering
+	// DNSServers is a list of custom DNS server IP addresses of the VNet. If not set, the Azure-provided DNS is used.
+	// It can only be set for VNets which are created for the cluster.
+	DNSServers []string
+	// PrivateDNSZoneIDs is a list of resource IDs of private DNS zones which are linked to the VNet. The zones must
+	// belong to the subscription of the shoot.
+	PrivateDNSZoneIDs []string
+}
+
+// VNetPeering is a peering from the VNet to a remote VNet.
+type VNetPeering struct {
+	// Name is the name of the peering.
+	Name string
+	// RemoteVNetID is the resource ID of the remote VNet. It may belong to another subscription.
+	RemoteVNetID string
+	// AllowForwardedTraffic indicates whether traffic forwarded by the remote VNet is allowed.
+	AllowForwardedTraffic bool
+	// UseRemoteGateways indicates whether the gateways of the remote VNet are used.
+	UseRemoteGateways bool
+	// RemoteAddressSpaces is a list of known address spaces of the remote VNet. If given, they are validated to not
+	// overlap with the networks of the cluster.
+	RemoteAddressSpaces []string
 }
 
 // VNetStatus contains the VNet name.
@@ -134,4 +336,26 @@ type VNetStatus struct {
 	Name string
 	// ResourceGroup is the resource group where the existing vNet belongs to.
 	ResourceGroup *string
+	// Peerings are the peerings of the VNet.
+	Peerings []VNetPeeringStatus
+	// PrivateDNSZoneLinks are the links of private DNS zones to the VNet.
+	PrivateDNSZoneLinks []PrivateDNSZoneLinkStatus
+}
+
+// PrivateDNSZoneLinkStatus contains information about the link of a private DNS zone to the VNet.
+type PrivateDNSZoneLinkStatus struct {
+	// PrivateDNSZoneID is the resource ID of the linked private DNS zone.
+	PrivateDNSZoneID string
+	// ID is the id of the virtual network link.
+	ID string
+}
+
+// VNetPeeringStatus contains information about a VNet peering.
+type VNetPeeringStatus struct {
+	// Name is the name of the peering.
+	Name string
+	// ID is the id of the peering.
+	ID string
+	// State is the peering state as reported by Azure, e.g. Initiated or Connected.
+	State string
 }
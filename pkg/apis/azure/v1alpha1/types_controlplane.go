@@ -28,6 +28,48 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+
+	// LoadBalancerSKU is the SKU of the load balancers managed by the cloud-controller-manager. Non-zoned clusters using
+	// basic load balancers are migrated to standard load balancers if it is set to `standard`.
+	// +optional
+	LoadBalancerSKU *LoadBalancerSKU `json:"loadBalancerSKU,omitempty"`
+
+	// CloudProviderConfig contains rate limit and backoff settings for the calls of the cloud provider to the Azure API.
+	// They are used by the cloud-controller-manager and the kubelets.
+	// +optional
+	CloudProviderConfig *CloudProviderConfig `json:"cloudProviderConfig,omitempty"`
+
+	// VolumeSnapshots contains settings for the snapshots of Azure Disk volumes.
+	// +optional
+	VolumeSnapshots *VolumeSnapshotConfig `json:"volumeSnapshots,omitempty"`
+
+	// StorageClasses contains the storage classes which are deployed into the cluster in addition to or instead of the
+	// built-in storage classes.
+	// +optional
+	StorageClasses *StorageClasses `json:"storageClasses,omitempty"`
+
+	// KubeAPIServerExposure specifies whether the kube-apiserver is exposed on a public or on an internal load balancer
+	// in the virtual network of the seed. Defaults to the setting of the seed.
+	// +optional
+	KubeAPIServerExposure *KubeAPIServerExposure `json:"kubeAPIServerExposure,omitempty"`
+
+	// PrivateLinkService exposes the kube-apiserver via an Azure Private Link Service which can be consumed from other
+	// virtual networks and tenants. It requires the kube-apiserver to be exposed on an internal load balancer.
+	// +optional
+	PrivateLinkService *PrivateLinkServiceConfig `json:"privateLinkService,omitempty"`
+
+	// KMS configures the encryption of the secrets of the cluster in etcd with a key held in Azure Key Vault.
+	// +optional
+	KMS *KMSConfig `json:"kms,omitempty"`
+
+	// AzureAD configures the kube-apiserver to authenticate users with their Azure Active Directory identities.
+	// +optional
+	AzureAD *AzureADConfig `json:"azureAD,omitempty"`
+
+	// ACRCredentialProvider configures the kubelets to pull images from Azure Container Registries without image pull
+	// secrets. It requires Kubernetes version >= 1.20.
+	// +optional
+	ACRCredentialProvider *ACRCredentialProviderConfig `json:"acrCredentialProvider,omitempty"`
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
@@ -36,3 +78,194 @@ type CloudControllerManagerConfig struct {
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
 }
+
+// LoadBalancerSKU is the SKU of a load balancer.
+type LoadBalancerSKU string
+
+const (
+	// LoadBalancerSKUBasic is the basic load balancer SKU.
+	LoadBalancerSKUBasic LoadBalancerSKU = "basic"
+	// LoadBalancerSKUStandard is the standard load balancer SKU.
+	LoadBalancerSKUStandard LoadBalancerSKU = "standard"
+)
+
+// KubeAPIServerExposure specifies how the kube-apiserver is exposed.
+type KubeAPIServerExposure string
+
+const (
+	// KubeAPIServerExposurePublic exposes the kube-apiserver on a public load balancer.
+	KubeAPIServerExposurePublic KubeAPIServerExposure = "public"
+	// KubeAPIServerExposurePrivate exposes the kube-apiserver on an internal load balancer in the virtual network of the
+	// seed.
+	KubeAPIServerExposurePrivate KubeAPIServerExposure = "private"
+)
+
+// CloudProviderConfig contains rate limit and backoff settings for the calls of the cloud provider to the Azure API.
+type CloudProviderConfig struct {
+	// RateLimit contains the rate limit settings.
+	// +optional
+	RateLimit *CloudProviderRateLimit `json:"rateLimit,omitempty"`
+	// Backoff contains the backoff settings for retrying failed calls.
+	// +optional
+	Backoff *CloudProviderBackoff `json:"backoff,omitempty"`
+}
+
+// CloudProviderRateLimit contains the rate limit settings for the calls of the cloud provider to the Azure API.
+type CloudProviderRateLimit struct {
+	// Enabled specifies whether the calls are rate limited. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// QPS is the number of read calls per second. Defaults to the maximum number of nodes of the cluster but at least 10.
+	// +optional
+	QPS *float64 `json:"qps,omitempty"`
+	// Bucket is the number of read calls which may be sent in a burst. Defaults to 100.
+	// +optional
+	Bucket *int32 `json:"bucket,omitempty"`
+	// QPSWrite is the number of write calls per second. Defaults to the QPS of the read calls.
+	// +optional
+	QPSWrite *float64 `json:"qpsWrite,omitempty"`
+	// BucketWrite is the number of write calls which may be sent in a burst. Defaults to 100.
+	// +optional
+	BucketWrite *int32 `json:"bucketWrite,omitempty"`
+}
+
+// CloudProviderBackoff contains the backoff settings for retrying failed calls of the cloud provider to the Azure API.
+type CloudProviderBackoff struct {
+	// Enabled specifies whether failed calls are retried. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Retries is the number of retries. Defaults to 6.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// Exponent is the factor by which the duration is multiplied with each retry. Defaults to 1.5.
+	// +optional
+	Exponent *float64 `json:"exponent,omitempty"`
+	// Duration is the initial duration between retries in seconds. Defaults to 5.
+	// +optional
+	Duration *int32 `json:"duration,omitempty"`
+	// Jitter is the jitter factor applied to the duration. Defaults to 1.0.
+	// +optional
+	Jitter *float64 `json:"jitter,omitempty"`
+}
+
+// VolumeSnapshotConfig contains settings for the snapshots of Azure Disk volumes.
+type VolumeSnapshotConfig struct {
+	// ResourceGroup is the name of the resource group in which the snapshots are stored. Defaults to the resource group
+	// of the volumes.
+	// +optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+}
+
+// PrivateLinkServiceConfig contains settings for the Azure Private Link Service of the kube-apiserver.
+type PrivateLinkServiceConfig struct {
+	// Visibility is the list of subscriptions which can find the Private Link Service and request connections to it.
+	// `*` makes it visible to all subscriptions.
+	// +optional
+	Visibility []string `json:"visibility,omitempty"`
+	// AutoApproval is the list of subscriptions whose connection requests are approved automatically.
+	// +optional
+	AutoApproval []string `json:"autoApproval,omitempty"`
+}
+
+// KMSConfig contains settings for the encryption of secrets with a key held in Azure Key Vault.
+type KMSConfig struct {
+	// KeyVaultName is the name of the Azure Key Vault which holds the key.
+	KeyVaultName string `json:"keyVaultName"`
+	// KeyName is the name of the key.
+	KeyName string `json:"keyName"`
+	// KeyVersion is the version of the key which is used to encrypt secrets. Changing it rotates the key, the
+	// former versions are kept until all secrets are encrypted with the new version.
+	KeyVersion string `json:"keyVersion"`
+}
+
+// AzureADConfig contains settings for the authentication of users with their Azure Active Directory identities.
+type AzureADConfig struct {
+	// TenantID is the ID of the Azure Active Directory tenant which issues the tokens.
+	TenantID string `json:"tenantID"`
+	// ClientID is the ID of the application the tokens are issued for.
+	ClientID string `json:"clientID"`
+	// UsernameClaim is the claim of the tokens which is used as user name. Defaults to `oid`.
+	// +optional
+	UsernameClaim *string `json:"usernameClaim,omitempty"`
+	// UsernamePrefix is prepended to the user names to prevent clashes with other authentication methods.
+	// +optional
+	UsernamePrefix *string `json:"usernamePrefix,omitempty"`
+	// GroupsClaim is the claim of the tokens which contains the groups of the user. Defaults to `groups`.
+	// +optional
+	GroupsClaim *string `json:"groupsClaim,omitempty"`
+	// GroupsPrefix is prepended to the groups to prevent clashes with other authentication methods.
+	// +optional
+	GroupsPrefix *string `json:"groupsPrefix,omitempty"`
+	// ValidateTenant specifies whether the tenant must be the tenant of the cloud provider credentials of the shoot.
+	// +optional
+	ValidateTenant *bool `json:"validateTenant,omitempty"`
+}
+
+// ACRCredentialProviderConfig contains settings for the image credential provider of the kubelets for Azure Container
+// Registries.
+type ACRCredentialProviderConfig struct {
+	// Registries is the list of registries the kubelets get credentials for, e.g. `myregistry.azurecr.io`. A leading
+	// `*.` matches all subdomains, e.g. `*.azurecr.io`.
+	Registries []string `json:"registries"`
+	// ManagedIdentityClientID is the client ID of the user-assigned managed identity of the nodes which is allowed to
+	// pull images from the registries.
+	ManagedIdentityClientID string `json:"managedIdentityClientID"`
+	// ManagedIdentityID is the resource ID of the user-assigned managed identity of the nodes. It is assigned to the
+	// virtual machines of all worker pools.
+	ManagedIdentityID string `json:"managedIdentityID"`
+}
+
+// StorageClasses contains the storage classes which are deployed into the cluster.
+type StorageClasses struct {
+	// Mode specifies whether the given storage classes extend or replace the built-in storage classes. Storage classes
+	// with the name of a built-in storage class replace it in both modes. Defaults to `Extend`.
+	// +optional
+	Mode *StorageClassesMode `json:"mode,omitempty"`
+	// Classes is the list of Azure Disk storage classes.
+	// +optional
+	Classes []StorageClass `json:"classes,omitempty"`
+}
+
+// StorageClassesMode specifies how the given storage classes are combined with the built-in storage classes.
+type StorageClassesMode string
+
+const (
+	// StorageClassesModeExtend deploys the given storage classes in addition to the built-in storage classes.
+	StorageClassesModeExtend StorageClassesMode = "Extend"
+	// StorageClassesModeReplace deploys only the given storage classes.
+	StorageClassesModeReplace StorageClassesMode = "Replace"
+)
+
+// StorageClass is an Azure Disk storage class.
+type StorageClass struct {
+	// Name is the name of the storage class.
+	Name string `json:"name"`
+	// SKU is the SKU of the disks, e.g. `Premium_LRS` or `StandardSSD_ZRS`.
+	SKU string `json:"sku"`
+	// Default specifies whether the storage class is the default storage class of the cluster.
+	// +optional
+	Default *bool `json:"default,omitempty"`
+	// VolumeBindingMode is the binding mode of the storage class, either `Immediate` or `WaitForFirstConsumer`. Defaults
+	// to `WaitForFirstConsumer` for clusters using the CSI driver and to `Immediate` otherwise.
+	// +optional
+	VolumeBindingMode *string `json:"volumeBindingMode,omitempty"`
+	// ReclaimPolicy is the reclaim policy of the volumes of the storage class, either `Delete` or `Retain`. Defaults to
+	// `Delete`.
+	// +optional
+	ReclaimPolicy *string `json:"reclaimPolicy,omitempty"`
+	// Parameters are additional parameters of the storage class, e.g. `cachingmode`.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControlPlaneStatus contains the status of the control plane.
+type ControlPlaneStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// PrivateLinkServiceAlias is the alias of the Azure Private Link Service of the kube-apiserver. Consumers use it to
+	// create private endpoints.
+	// +optional
+	PrivateLinkServiceAlias *string `json:"privateLinkServiceAlias,omitempty"`
+}
@@ -32,6 +32,24 @@ type InfrastructureConfig struct {
 	// Zoned indicates whether the cluster uses availability zones.
 	// +optional
 	Zoned bool `json:"zoned,omitempty"`
+	// ProximityPlacementGroups is a list of proximity placement groups which should be created. Worker pools can
+	// reference them in their provider config to co-locate their machines.
+	// +optional
+	ProximityPlacementGroups []ProximityPlacementGroup `json:"proximityPlacementGroups,omitempty"`
+	// AvailabilitySetPerWorkerPool indicates whether one availability set per worker pool shall be created for a non
+	// zoned cluster instead of a single availability set shared by all worker pools.
+	// +optional
+	AvailabilitySetPerWorkerPool bool `json:"availabilitySetPerWorkerPool,omitempty"`
+}
+
+// ProximityPlacementGroup is a proximity placement group which should be created.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group.
+	Name string `json:"name"`
+	// Zone is the zone the proximity placement group is created for. It must be set for zoned clusters, a group
+	// of the same name can be declared once per zone. It must not be set for non-zoned clusters.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
 }
 
 // ResourceGroup is azure resource group
@@ -45,12 +63,159 @@ type NetworkConfig struct {
 	// VNet indicates whether to use an existing VNet or create a new one.
 	VNet VNet `json:"vnet"`
 	// Workers is the worker subnet range to create (used for the VMs).
-	Workers string `json:"workers"`
+	// It must not be set if Zones is specified.
+	// +optional
+	Workers string `json:"workers,omitempty"`
+	// IPv6Workers is the IPv6 range of the worker subnet for dual-stack clusters. If set, the worker subnet and the VMs
+	// get an IPv6 address prefix and address in addition to the IPv4 one. It must not be set if Zones is specified.
+	// +optional
+	IPv6Workers *string `json:"ipv6Workers,omitempty"`
 	// ServiceEndpoints is a list of Azure ServiceEndpoints which should be associated with the worker subnet.
 	// +optional
 	ServiceEndpoints []string `json:"serviceEndpoints,omitempty"`
+	// ApplicationSecurityGroups is a list of application security groups which should be created.
+	// +optional
+	ApplicationSecurityGroups []ApplicationSecurityGroup `json:"applicationSecurityGroups,omitempty"`
+	// SecurityRules is a list of additional rules which should be added to the worker network security group.
+	// +optional
+	SecurityRules []SecurityRule `json:"securityRules,omitempty"`
+	// Subnets is a list of additional worker subnets which should be created. Worker pools can select one of them
+	// in their provider config.
+	// +optional
+	Subnets []WorkerSubnet `json:"subnets,omitempty"`
+	// Zones is a list of zones with one worker subnet per zone. It can only be used for zoned clusters and is mutually
+	// exclusive with Workers.
+	// +optional
+	Zones []Zone `json:"zones,omitempty"`
+	// Routes is a list of user-defined routes which should be added to the worker route table.
+	// +optional
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// Route is a user-defined route of the worker route table.
+type Route struct {
+	// Name is the name of the route.
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR the route applies to.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of the hop the traffic is sent to.
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address the traffic is forwarded to. It must only be set if the next hop type is VirtualAppliance.
+	// +optional
+	NextHopIPAddress *string `json:"nextHopIPAddress,omitempty"`
 }
 
+// RouteNextHopType is the type of the next hop of a route.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway forwards the traffic to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway RouteNextHopType = "VirtualNetworkGateway"
+	// RouteNextHopTypeVnetLocal keeps the traffic within the virtual network.
+	RouteNextHopTypeVnetLocal RouteNextHopType = "VnetLocal"
+	// RouteNextHopTypeInternet forwards the traffic to the internet.
+	RouteNextHopTypeInternet RouteNextHopType = "Internet"
+	// RouteNextHopTypeVirtualAppliance forwards the traffic to a virtual appliance, e.g. a firewall.
+	RouteNextHopTypeVirtualAppliance RouteNextHopType = "VirtualAppliance"
+	// RouteNextHopTypeNone drops the traffic.
+	RouteNextHopTypeNone RouteNextHopType = "None"
+)
+
+// Zone describes the worker subnet of an availability zone.
+type Zone struct {
+	// Name is the name of the zone.
+	Name string `json:"name"`
+	// CIDR is the CIDR of the worker subnet of the zone.
+	CIDR string `json:"cidr"`
+}
+
+// WorkerSubnet is an additional worker subnet which should be created.
+type WorkerSubnet struct {
+	// Name is the name of the subnet.
+	Name string `json:"name"`
+	// CIDR is the CIDR of the subnet. It must be contained in the VNet CIDR.
+	CIDR string `json:"cidr"`
+	// ServiceEndpoints is a list of Azure ServiceEndpoints which should be associated with the subnet.
+	// +optional
+	ServiceEndpoints []string `json:"serviceEndpoints,omitempty"`
+	// SecurityGroup is the name of a dedicated network security group which should be created for the subnet.
+	// If not set, the subnet is associated with the worker network security group.
+	// +optional
+	SecurityGroup *string `json:"securityGroup,omitempty"`
+}
+
+// ApplicationSecurityGroup is an application security group which should be created.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
+}
+
+// SecurityRule is a rule of the worker network security group.
+type SecurityRule struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+	// Priority is the priority of the rule. Rules with lower values are evaluated first.
+	Priority int32 `json:"priority"`
+	// Direction is the direction of the traffic the rule applies to.
+	Direction SecurityRuleDirection `json:"direction"`
+	// Access specifies whether matching traffic is allowed or denied.
+	Access SecurityRuleAccess `json:"access"`
+	// Protocol is the network protocol the rule applies to.
+	Protocol SecurityRuleProtocol `json:"protocol"`
+	// SourceAddressPrefix is the CIDR or source IP range the rule applies to.
+	// +optional
+	SourceAddressPrefix *string `json:"sourceAddressPrefix,omitempty"`
+	// SourcePortRange is the source port or port range the rule applies to.
+	// +optional
+	SourcePortRange *string `json:"sourcePortRange,omitempty"`
+	// SourceApplicationSecurityGroups is a list of names of application security groups which are the source of the rule.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationAddressPrefix is the CIDR or destination IP range the rule applies to.
+	// +optional
+	DestinationAddressPrefix *string `json:"destinationAddressPrefix,omitempty"`
+	// DestinationPortRange is the destination port or port range the rule applies to.
+	// +optional
+	DestinationPortRange *string `json:"destinationPortRange,omitempty"`
+	// DestinationApplicationSecurityGroups is a list of names of application security groups which are the destination of the rule.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
+}
+
+// SecurityRuleDirection is the direction of a security rule.
+type SecurityRuleDirection string
+
+const (
+	// SecurityRuleDirectionInbound is the direction for incoming traffic.
+	SecurityRuleDirectionInbound SecurityRuleDirection = "Inbound"
+	// SecurityRuleDirectionOutbound is the direction for outgoing traffic.
+	SecurityRuleDirectionOutbound SecurityRuleDirection = "Outbound"
+)
+
+// SecurityRuleAccess specifies whether a security rule allows or denies traffic.
+type SecurityRuleAccess string
+
+const (
+	// SecurityRuleAccessAllow allows matching traffic.
+	SecurityRuleAccessAllow SecurityRuleAccess = "Allow"
+	// SecurityRuleAccessDeny denies matching traffic.
+	SecurityRuleAccessDeny SecurityRuleAccess = "Deny"
+)
+
+// SecurityRuleProtocol is the network protocol of a security rule.
+type SecurityRuleProtocol string
+
+const (
+	// SecurityRuleProtocolTCP is the TCP protocol.
+	SecurityRuleProtocolTCP SecurityRuleProtocol = "Tcp"
+	// SecurityRuleProtocolUDP is the UDP protocol.
+	SecurityRuleProtocolUDP SecurityRuleProtocol = "Udp"
+	// SecurityRuleProtocolICMP is the ICMP protocol.
+	SecurityRuleProtocolICMP SecurityRuleProtocol = "Icmp"
+	// SecurityRuleProtocolAll matches all protocols.
+	SecurityRuleProtocolAll SecurityRuleProtocol = "*"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // InfrastructureStatus contains information about created infrastructure resources.
@@ -66,9 +231,28 @@ type InfrastructureStatus struct {
 	RouteTables []RouteTable `json:"routeTables"`
 	// SecurityGroups is a list of created security groups
 	SecurityGroups []SecurityGroup `json:"securityGroups"`
+	// ApplicationSecurityGroups is a list of created application security groups
+	// +optional
+	ApplicationSecurityGroups []ApplicationSecurityGroupStatus `json:"applicationSecurityGroups,omitempty"`
 	// Zoned indicates whether the cluster uses zones
 	// +optional
 	Zoned bool `json:"zoned,omitempty"`
+	// ProximityPlacementGroups is a list of created proximity placement groups
+	// +optional
+	ProximityPlacementGroups []ProximityPlacementGroupStatus `json:"proximityPlacementGroups,omitempty"`
+}
+
+// ProximityPlacementGroupStatus contains information about a created proximity placement group.
+type ProximityPlacementGroupStatus struct {
+	// Name is the name of the proximity placement group as declared in the InfrastructureConfig.
+	Name string `json:"name"`
+	// ID is the id of the proximity placement group.
+	ID string `json:"id"`
+	// Purpose is the purpose of the proximity placement group.
+	Purpose Purpose `json:"purpose"`
+	// Zone is the zone the proximity placement group was created for, if any.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
 }
 
 // NetworkStatus is the current status of the infrastructure networks.
@@ -96,6 +280,12 @@ type Subnet struct {
 	Name string `json:"name"`
 	// Purpose is the purpose for which the subnet was created.
 	Purpose Purpose `json:"purpose"`
+	// Zone is the name of the zone the subnet was created for, if any.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// DualStack indicates whether the subnet has an IPv6 address prefix in addition to the IPv4 one.
+	// +optional
+	DualStack bool `json:"dualStack,omitempty"`
 }
 
 // AvailabilitySet contains information about the azure availability set
@@ -106,6 +296,10 @@ type AvailabilitySet struct {
 	ID string `json:"id"`
 	// Name is the name of the availability set
 	Name string `json:"name"`
+	// WorkerPool is the name of the worker pool the availability set belongs to. It is not set if the availability
+	// set is shared by all worker pools.
+	// +optional
+	WorkerPool *string `json:"workerPool,omitempty"`
 }
 
 // RouteTable is the azure route table
@@ -124,6 +318,14 @@ type SecurityGroup struct {
 	Name string `json:"name"`
 }
 
+// ApplicationSecurityGroupStatus contains information about a created application security group
+type ApplicationSecurityGroupStatus struct {
+	// Name is the name of the application security group
+	Name string `json:"name"`
+	// ID is the id of the application security group
+	ID string `json:"id"`
+}
+
 // VNet contains information about the VNet and some related resources.
 type VNet struct {
 	// Name is the name of an existing vNet which should be used.
@@ -135,6 +337,38 @@ type VNet struct {
 	// CIDR is the VNet CIDR
 	// +optional
 	CIDR *string `json:"cidr,omitempty"`
+	// IPv6CIDR is the IPv6 address space of the VNet for dual-stack clusters. If not set, the IPv6 worker range is used.
+	// +optional
+	IPv6CIDR *string `json:"ipv6CIDR,omitempty"`
+	// Peerings is a list of peerings from the VNet to remote VNets, e.g. to a central hub VNet.
+	// +optional
+	Peerings []VNetPeering `json:"peerings,omitempty"`
+	// DNSServers is a list of custom DNS server IP addresses of the VNet. If not set, the Azure-provided DNS is used.
+	// It can only be set for VNets which are created for the cluster.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// PrivateDNSZoneIDs is a list of resource IDs of private DNS zones which are linked to the VNet. The zones must
+	// belong to the subscription of the shoot.
+	// +optional
+	PrivateDNSZoneIDs []string `json:"privateDNSZoneIDs,omitempty"`
+}
+
+// VNetPeering is a peering from the VNet to a remote VNet.
+type VNetPeering struct {
+	// Name is the name of the peering.
+	Name string `json:"name"`
+	// RemoteVNetID is the resource ID of the remote VNet. It may belong to another subscription.
+	RemoteVNetID string `json:"remoteVNetID"`
+	// AllowForwardedTraffic indicates whether traffic forwarded by the remote VNet is allowed.
+	// +optional
+	AllowForwardedTraffic bool `json:"allowForwardedTraffic,omitempty"`
+	// UseRemoteGateways indicates whether the gateways of the remote VNet are used.
+	// +optional
+	UseRemoteGateways bool `json:"useRemoteGateways,omitempty"`
+	// RemoteAddressSpaces is a list of known address spaces of the remote VNet. If given, they are validated to not
+	// overlap with the networks of the cluster.
+	// +optional
+	RemoteAddressSpaces []string `json:"remoteAddressSpaces,omitempty"`
 }
 
 // VNetStatus contains the VNet name.
@@ -144,4 +378,29 @@ type VNetStatus struct {
 	// ResourceGroup is the resource group where the existing vNet belongs to.
 	// +optional
 	ResourceGroup *string `json:"resourceGroup,omitempty"`
+	// Peerings are the peerings of the VNet.
+	// +optional
+	Peerings []VNetPeeringStatus `json:"peerings,omitempty"`
+	// PrivateDNSZoneLinks are the links of private DNS zones to the VNet.
+	// +optional
+	PrivateDNSZoneLinks []PrivateDNSZoneLinkStatus `json:"privateDNSZoneLinks,omitempty"`
+}
+
+// PrivateDNSZoneLinkStatus contains information about the link of a private DNS zone to the VNet.
+type PrivateDNSZoneLinkStatus struct {
+	// PrivateDNSZoneID is the resource ID of the linked private DNS zone.
+	PrivateDNSZoneID string `json:"privateDNSZoneID"`
+	// ID is the id of the virtual network link.
+	ID string `json:"id"`
+}
+
+// VNetPeeringStatus contains information about a VNet peering.
+type VNetPeeringStatus struct {
+	// Name is the name of the peering.
+	Name string `json:"name"`
+	// ID is the id of the peering.
+	ID string `json:"id"`
+	// State is the peering state as reported by Azure, e.g. Initiated or Connected.
+	// +optional
+	State string `json:"state,omitempty"`
 }
@@ -21,6 +21,44 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// WorkerConfig contains configuration settings for the worker nodes.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ApplicationSecurityGroups is a list of names of application security groups the network interfaces of the
+	// worker nodes are attached to. The application security groups must be declared in the InfrastructureConfig.
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+	// Subnet is the name of the worker subnet the worker nodes are placed in. The subnet must be declared in the
+	// InfrastructureConfig. If not set, the default worker subnet is used.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+	// ProximityPlacementGroup is the name of the proximity placement group the worker nodes are placed in. The group
+	// must be declared in the InfrastructureConfig. For zoned clusters, the group of the respective zone is used.
+	// +optional
+	ProximityPlacementGroup *string `json:"proximityPlacementGroup,omitempty"`
+	// DedicatedHostGroup references an existing dedicated host group the worker nodes are placed in.
+	// +optional
+	DedicatedHostGroup *DedicatedHostGroup `json:"dedicatedHostGroup,omitempty"`
+}
+
+// DedicatedHostGroup references an existing dedicated host group and optionally a specific host of it.
+type DedicatedHostGroup struct {
+	// ID is the resource ID of the dedicated host group.
+	ID string `json:"id"`
+	// Zone is the availability zone of the dedicated host group. It is required for zoned clusters and must match
+	// the zones of the worker pool.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// HostID is the resource ID of a dedicated host of the group the worker nodes are placed on. If not set, Azure
+	// automatically places the worker nodes on one of the hosts of the group.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // WorkerStatus contains information about created worker resources.
 type WorkerStatus struct {
 	metav1.TypeMeta `json:",inline"`
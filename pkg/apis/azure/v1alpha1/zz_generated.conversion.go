@@ -35,6 +35,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ACRCredentialProviderConfig)(nil), (*azure.ACRCredentialProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ACRCredentialProviderConfig_To_azure_ACRCredentialProviderConfig(a.(*ACRCredentialProviderConfig), b.(*azure.ACRCredentialProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ACRCredentialProviderConfig)(nil), (*ACRCredentialProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ACRCredentialProviderConfig_To_v1alpha1_ACRCredentialProviderConfig(a.(*azure.ACRCredentialProviderConfig), b.(*ACRCredentialProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ApplicationSecurityGroup)(nil), (*azure.ApplicationSecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ApplicationSecurityGroup_To_azure_ApplicationSecurityGroup(a.(*ApplicationSecurityGroup), b.(*azure.ApplicationSecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ApplicationSecurityGroup)(nil), (*ApplicationSecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ApplicationSecurityGroup_To_v1alpha1_ApplicationSecurityGroup(a.(*azure.ApplicationSecurityGroup), b.(*ApplicationSecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ApplicationSecurityGroupStatus)(nil), (*azure.ApplicationSecurityGroupStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ApplicationSecurityGroupStatus_To_azure_ApplicationSecurityGroupStatus(a.(*ApplicationSecurityGroupStatus), b.(*azure.ApplicationSecurityGroupStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ApplicationSecurityGroupStatus)(nil), (*ApplicationSecurityGroupStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ApplicationSecurityGroupStatus_To_v1alpha1_ApplicationSecurityGroupStatus(a.(*azure.ApplicationSecurityGroupStatus), b.(*ApplicationSecurityGroupStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AvailabilitySet)(nil), (*azure.AvailabilitySet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_AvailabilitySet_To_azure_AvailabilitySet(a.(*AvailabilitySet), b.(*azure.AvailabilitySet), scope)
 	}); err != nil {
@@ -45,6 +75,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureADConfig)(nil), (*azure.AzureADConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AzureADConfig_To_azure_AzureADConfig(a.(*AzureADConfig), b.(*azure.AzureADConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.AzureADConfig)(nil), (*AzureADConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_AzureADConfig_To_v1alpha1_AzureADConfig(a.(*azure.AzureADConfig), b.(*AzureADConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*azure.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*azure.CloudControllerManagerConfig), scope)
 	}); err != nil {
@@ -65,6 +105,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderBackoff)(nil), (*azure.CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(a.(*CloudProviderBackoff), b.(*azure.CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProviderBackoff)(nil), (*CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(a.(*azure.CloudProviderBackoff), b.(*CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderConfig)(nil), (*azure.CloudProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderConfig_To_azure_CloudProviderConfig(a.(*CloudProviderConfig), b.(*azure.CloudProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProviderConfig)(nil), (*CloudProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProviderConfig_To_v1alpha1_CloudProviderConfig(a.(*azure.CloudProviderConfig), b.(*CloudProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderRateLimit)(nil), (*azure.CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(a.(*CloudProviderRateLimit), b.(*azure.CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProviderRateLimit)(nil), (*CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(a.(*azure.CloudProviderRateLimit), b.(*CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*azure.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*azure.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -75,6 +145,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneStatus)(nil), (*azure.ControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(a.(*ControlPlaneStatus), b.(*azure.ControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ControlPlaneStatus)(nil), (*ControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(a.(*azure.ControlPlaneStatus), b.(*ControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DedicatedHostGroup)(nil), (*azure.DedicatedHostGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DedicatedHostGroup_To_azure_DedicatedHostGroup(a.(*DedicatedHostGroup), b.(*azure.DedicatedHostGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.DedicatedHostGroup)(nil), (*DedicatedHostGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_DedicatedHostGroup_To_v1alpha1_DedicatedHostGroup(a.(*azure.DedicatedHostGroup), b.(*DedicatedHostGroup), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DomainCount)(nil), (*azure.DomainCount)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DomainCount_To_azure_DomainCount(a.(*DomainCount), b.(*azure.DomainCount), scope)
 	}); err != nil {
@@ -105,6 +195,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMSConfig)(nil), (*azure.KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMSConfig_To_azure_KMSConfig(a.(*KMSConfig), b.(*azure.KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.KMSConfig)(nil), (*KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_KMSConfig_To_v1alpha1_KMSConfig(a.(*azure.KMSConfig), b.(*KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*azure.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineImage_To_azure_MachineImage(a.(*MachineImage), b.(*azure.MachineImage), scope)
 	}); err != nil {
@@ -155,6 +255,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrivateDNSZoneLinkStatus)(nil), (*azure.PrivateDNSZoneLinkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrivateDNSZoneLinkStatus_To_azure_PrivateDNSZoneLinkStatus(a.(*PrivateDNSZoneLinkStatus), b.(*azure.PrivateDNSZoneLinkStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.PrivateDNSZoneLinkStatus)(nil), (*PrivateDNSZoneLinkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_PrivateDNSZoneLinkStatus_To_v1alpha1_PrivateDNSZoneLinkStatus(a.(*azure.PrivateDNSZoneLinkStatus), b.(*PrivateDNSZoneLinkStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrivateLinkServiceConfig)(nil), (*azure.PrivateLinkServiceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrivateLinkServiceConfig_To_azure_PrivateLinkServiceConfig(a.(*PrivateLinkServiceConfig), b.(*azure.PrivateLinkServiceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.PrivateLinkServiceConfig)(nil), (*PrivateLinkServiceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(a.(*azure.PrivateLinkServiceConfig), b.(*PrivateLinkServiceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProximityPlacementGroup)(nil), (*azure.ProximityPlacementGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProximityPlacementGroup_To_azure_ProximityPlacementGroup(a.(*ProximityPlacementGroup), b.(*azure.ProximityPlacementGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ProximityPlacementGroup)(nil), (*ProximityPlacementGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ProximityPlacementGroup_To_v1alpha1_ProximityPlacementGroup(a.(*azure.ProximityPlacementGroup), b.(*ProximityPlacementGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProximityPlacementGroupStatus)(nil), (*azure.ProximityPlacementGroupStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProximityPlacementGroupStatus_To_azure_ProximityPlacementGroupStatus(a.(*ProximityPlacementGroupStatus), b.(*azure.ProximityPlacementGroupStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ProximityPlacementGroupStatus)(nil), (*ProximityPlacementGroupStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ProximityPlacementGroupStatus_To_v1alpha1_ProximityPlacementGroupStatus(a.(*azure.ProximityPlacementGroupStatus), b.(*ProximityPlacementGroupStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ResourceGroup)(nil), (*azure.ResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ResourceGroup_To_azure_ResourceGroup(a.(*ResourceGroup), b.(*azure.ResourceGroup), scope)
 	}); err != nil {
@@ -165,6 +305,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Route)(nil), (*azure.Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Route_To_azure_Route(a.(*Route), b.(*azure.Route), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.Route)(nil), (*Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_Route_To_v1alpha1_Route(a.(*azure.Route), b.(*Route), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RouteTable)(nil), (*azure.RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RouteTable_To_azure_RouteTable(a.(*RouteTable), b.(*azure.RouteTable), scope)
 	}); err != nil {
@@ -185,6 +335,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SecurityRule)(nil), (*azure.SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecurityRule_To_azure_SecurityRule(a.(*SecurityRule), b.(*azure.SecurityRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.SecurityRule)(nil), (*SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_SecurityRule_To_v1alpha1_SecurityRule(a.(*azure.SecurityRule), b.(*SecurityRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageClass)(nil), (*azure.StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageClass_To_azure_StorageClass(a.(*StorageClass), b.(*azure.StorageClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.StorageClass)(nil), (*StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_StorageClass_To_v1alpha1_StorageClass(a.(*azure.StorageClass), b.(*StorageClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageClasses)(nil), (*azure.StorageClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageClasses_To_azure_StorageClasses(a.(*StorageClasses), b.(*azure.StorageClasses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.StorageClasses)(nil), (*StorageClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_StorageClasses_To_v1alpha1_StorageClasses(a.(*azure.StorageClasses), b.(*StorageClasses), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Subnet)(nil), (*azure.Subnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Subnet_To_azure_Subnet(a.(*Subnet), b.(*azure.Subnet), scope)
 	}); err != nil {
@@ -205,6 +385,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VNetPeering)(nil), (*azure.VNetPeering)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VNetPeering_To_azure_VNetPeering(a.(*VNetPeering), b.(*azure.VNetPeering), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.VNetPeering)(nil), (*VNetPeering)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_VNetPeering_To_v1alpha1_VNetPeering(a.(*azure.VNetPeering), b.(*VNetPeering), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VNetPeeringStatus)(nil), (*azure.VNetPeeringStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VNetPeeringStatus_To_azure_VNetPeeringStatus(a.(*VNetPeeringStatus), b.(*azure.VNetPeeringStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.VNetPeeringStatus)(nil), (*VNetPeeringStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_VNetPeeringStatus_To_v1alpha1_VNetPeeringStatus(a.(*azure.VNetPeeringStatus), b.(*VNetPeeringStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VNetStatus)(nil), (*azure.VNetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VNetStatus_To_azure_VNetStatus(a.(*VNetStatus), b.(*azure.VNetStatus), scope)
 	}); err != nil {
@@ -215,6 +415,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VolumeSnapshotConfig)(nil), (*azure.VolumeSnapshotConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VolumeSnapshotConfig_To_azure_VolumeSnapshotConfig(a.(*VolumeSnapshotConfig), b.(*azure.VolumeSnapshotConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.VolumeSnapshotConfig)(nil), (*VolumeSnapshotConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_VolumeSnapshotConfig_To_v1alpha1_VolumeSnapshotConfig(a.(*azure.VolumeSnapshotConfig), b.(*VolumeSnapshotConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WorkerConfig)(nil), (*azure.WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkerConfig_To_azure_WorkerConfig(a.(*WorkerConfig), b.(*azure.WorkerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.WorkerConfig)(nil), (*WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_WorkerConfig_To_v1alpha1_WorkerConfig(a.(*azure.WorkerConfig), b.(*WorkerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerStatus)(nil), (*azure.WorkerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerStatus_To_azure_WorkerStatus(a.(*WorkerStatus), b.(*azure.WorkerStatus), scope)
 	}); err != nil {
@@ -225,13 +445,100 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkerSubnet)(nil), (*azure.WorkerSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkerSubnet_To_azure_WorkerSubnet(a.(*WorkerSubnet), b.(*azure.WorkerSubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.WorkerSubnet)(nil), (*WorkerSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_WorkerSubnet_To_v1alpha1_WorkerSubnet(a.(*azure.WorkerSubnet), b.(*WorkerSubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Zone)(nil), (*azure.Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Zone_To_azure_Zone(a.(*Zone), b.(*azure.Zone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.Zone)(nil), (*Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_Zone_To_v1alpha1_Zone(a.(*azure.Zone), b.(*Zone), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_ACRCredentialProviderConfig_To_azure_ACRCredentialProviderConfig(in *ACRCredentialProviderConfig, out *azure.ACRCredentialProviderConfig, s conversion.Scope) error {
+	out.Registries = *(*[]string)(unsafe.Pointer(&in.Registries))
+	out.ManagedIdentityClientID = in.ManagedIdentityClientID
+	out.ManagedIdentityID = in.ManagedIdentityID
 	return nil
 }
 
+// Convert_v1alpha1_ACRCredentialProviderConfig_To_azure_ACRCredentialProviderConfig is an autogenerated conversion function.
+func Convert_v1alpha1_ACRCredentialProviderConfig_To_azure_ACRCredentialProviderConfig(in *ACRCredentialProviderConfig, out *azure.ACRCredentialProviderConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ACRCredentialProviderConfig_To_azure_ACRCredentialProviderConfig(in, out, s)
+}
+
+func autoConvert_azure_ACRCredentialProviderConfig_To_v1alpha1_ACRCredentialProviderConfig(in *azure.ACRCredentialProviderConfig, out *ACRCredentialProviderConfig, s conversion.Scope) error {
+	out.Registries = *(*[]string)(unsafe.Pointer(&in.Registries))
+	out.ManagedIdentityClientID = in.ManagedIdentityClientID
+	out.ManagedIdentityID = in.ManagedIdentityID
+	return nil
+}
+
+// Convert_azure_ACRCredentialProviderConfig_To_v1alpha1_ACRCredentialProviderConfig is an autogenerated conversion function.
+func Convert_azure_ACRCredentialProviderConfig_To_v1alpha1_ACRCredentialProviderConfig(in *azure.ACRCredentialProviderConfig, out *ACRCredentialProviderConfig, s conversion.Scope) error {
+	return autoConvert_azure_ACRCredentialProviderConfig_To_v1alpha1_ACRCredentialProviderConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_ApplicationSecurityGroup_To_azure_ApplicationSecurityGroup(in *ApplicationSecurityGroup, out *azure.ApplicationSecurityGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_ApplicationSecurityGroup_To_azure_ApplicationSecurityGroup is an autogenerated conversion function.
+func Convert_v1alpha1_ApplicationSecurityGroup_To_azure_ApplicationSecurityGroup(in *ApplicationSecurityGroup, out *azure.ApplicationSecurityGroup, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ApplicationSecurityGroup_To_azure_ApplicationSecurityGroup(in, out, s)
+}
+
+func autoConvert_azure_ApplicationSecurityGroup_To_v1alpha1_ApplicationSecurityGroup(in *azure.ApplicationSecurityGroup, out *ApplicationSecurityGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_azure_ApplicationSecurityGroup_To_v1alpha1_ApplicationSecurityGroup is an autogenerated conversion function.
+func Convert_azure_ApplicationSecurityGroup_To_v1alpha1_ApplicationSecurityGroup(in *azure.ApplicationSecurityGroup, out *ApplicationSecurityGroup, s conversion.Scope) error {
+	return autoConvert_azure_ApplicationSecurityGroup_To_v1alpha1_ApplicationSecurityGroup(in, out, s)
+}
+
+func autoConvert_v1alpha1_ApplicationSecurityGroupStatus_To_azure_ApplicationSecurityGroupStatus(in *ApplicationSecurityGroupStatus, out *azure.ApplicationSecurityGroupStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_ApplicationSecurityGroupStatus_To_azure_ApplicationSecurityGroupStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ApplicationSecurityGroupStatus_To_azure_ApplicationSecurityGroupStatus(in *ApplicationSecurityGroupStatus, out *azure.ApplicationSecurityGroupStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ApplicationSecurityGroupStatus_To_azure_ApplicationSecurityGroupStatus(in, out, s)
+}
+
+func autoConvert_azure_ApplicationSecurityGroupStatus_To_v1alpha1_ApplicationSecurityGroupStatus(in *azure.ApplicationSecurityGroupStatus, out *ApplicationSecurityGroupStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_azure_ApplicationSecurityGroupStatus_To_v1alpha1_ApplicationSecurityGroupStatus is an autogenerated conversion function.
+func Convert_azure_ApplicationSecurityGroupStatus_To_v1alpha1_ApplicationSecurityGroupStatus(in *azure.ApplicationSecurityGroupStatus, out *ApplicationSecurityGroupStatus, s conversion.Scope) error {
+	return autoConvert_azure_ApplicationSecurityGroupStatus_To_v1alpha1_ApplicationSecurityGroupStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_AvailabilitySet_To_azure_AvailabilitySet(in *AvailabilitySet, out *azure.AvailabilitySet, s conversion.Scope) error {
 	out.Purpose = azure.Purpose(in.Purpose)
 	out.ID = in.ID
 	out.Name = in.Name
+	out.WorkerPool = (*string)(unsafe.Pointer(in.WorkerPool))
 	return nil
 }
 
@@ -244,6 +551,7 @@ func autoConvert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in *azure.Ava
 	out.Purpose = Purpose(in.Purpose)
 	out.ID = in.ID
 	out.Name = in.Name
+	out.WorkerPool = (*string)(unsafe.Pointer(in.WorkerPool))
 	return nil
 }
 
@@ -252,6 +560,38 @@ func Convert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in *azure.Availab
 	return autoConvert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in, out, s)
 }
 
+func autoConvert_v1alpha1_AzureADConfig_To_azure_AzureADConfig(in *AzureADConfig, out *azure.AzureADConfig, s conversion.Scope) error {
+	out.TenantID = in.TenantID
+	out.ClientID = in.ClientID
+	out.UsernameClaim = (*string)(unsafe.Pointer(in.UsernameClaim))
+	out.UsernamePrefix = (*string)(unsafe.Pointer(in.UsernamePrefix))
+	out.GroupsClaim = (*string)(unsafe.Pointer(in.GroupsClaim))
+	out.GroupsPrefix = (*string)(unsafe.Pointer(in.GroupsPrefix))
+	out.ValidateTenant = (*bool)(unsafe.Pointer(in.ValidateTenant))
+	return nil
+}
+
+// Convert_v1alpha1_AzureADConfig_To_azure_AzureADConfig is an autogenerated conversion function.
+func Convert_v1alpha1_AzureADConfig_To_azure_AzureADConfig(in *AzureADConfig, out *azure.AzureADConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AzureADConfig_To_azure_AzureADConfig(in, out, s)
+}
+
+func autoConvert_azure_AzureADConfig_To_v1alpha1_AzureADConfig(in *azure.AzureADConfig, out *AzureADConfig, s conversion.Scope) error {
+	out.TenantID = in.TenantID
+	out.ClientID = in.ClientID
+	out.UsernameClaim = (*string)(unsafe.Pointer(in.UsernameClaim))
+	out.UsernamePrefix = (*string)(unsafe.Pointer(in.UsernamePrefix))
+	out.GroupsClaim = (*string)(unsafe.Pointer(in.GroupsClaim))
+	out.GroupsPrefix = (*string)(unsafe.Pointer(in.GroupsPrefix))
+	out.ValidateTenant = (*bool)(unsafe.Pointer(in.ValidateTenant))
+	return nil
+}
+
+// Convert_azure_AzureADConfig_To_v1alpha1_AzureADConfig is an autogenerated conversion function.
+func Convert_azure_AzureADConfig_To_v1alpha1_AzureADConfig(in *azure.AzureADConfig, out *AzureADConfig, s conversion.Scope) error {
+	return autoConvert_azure_AzureADConfig_To_v1alpha1_AzureADConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *azure.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
@@ -296,8 +636,95 @@ func Convert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *azure.C
 	return autoConvert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in *CloudProviderBackoff, out *azure.CloudProviderBackoff, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.Duration = (*int32)(unsafe.Pointer(in.Duration))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in *CloudProviderBackoff, out *azure.CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *azure.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.Duration = (*int32)(unsafe.Pointer(in.Duration))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *azure.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudProviderConfig_To_azure_CloudProviderConfig(in *CloudProviderConfig, out *azure.CloudProviderConfig, s conversion.Scope) error {
+	out.RateLimit = (*azure.CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*azure.CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderConfig_To_azure_CloudProviderConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderConfig_To_azure_CloudProviderConfig(in *CloudProviderConfig, out *azure.CloudProviderConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderConfig_To_azure_CloudProviderConfig(in, out, s)
+}
+
+func autoConvert_azure_CloudProviderConfig_To_v1alpha1_CloudProviderConfig(in *azure.CloudProviderConfig, out *CloudProviderConfig, s conversion.Scope) error {
+	out.RateLimit = (*CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_azure_CloudProviderConfig_To_v1alpha1_CloudProviderConfig is an autogenerated conversion function.
+func Convert_azure_CloudProviderConfig_To_v1alpha1_CloudProviderConfig(in *azure.CloudProviderConfig, out *CloudProviderConfig, s conversion.Scope) error {
+	return autoConvert_azure_CloudProviderConfig_To_v1alpha1_CloudProviderConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in *CloudProviderRateLimit, out *azure.CloudProviderRateLimit, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in *CloudProviderRateLimit, out *azure.CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in, out, s)
+}
+
+func autoConvert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *azure.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
+	return nil
+}
+
+// Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *azure.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *ControlPlaneConfig, out *azure.ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*azure.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.LoadBalancerSKU = (*azure.LoadBalancerSKU)(unsafe.Pointer(in.LoadBalancerSKU))
+	out.CloudProviderConfig = (*azure.CloudProviderConfig)(unsafe.Pointer(in.CloudProviderConfig))
+	out.VolumeSnapshots = (*azure.VolumeSnapshotConfig)(unsafe.Pointer(in.VolumeSnapshots))
+	out.StorageClasses = (*azure.StorageClasses)(unsafe.Pointer(in.StorageClasses))
+	out.KubeAPIServerExposure = (*azure.KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.PrivateLinkService = (*azure.PrivateLinkServiceConfig)(unsafe.Pointer(in.PrivateLinkService))
+	out.KMS = (*azure.KMSConfig)(unsafe.Pointer(in.KMS))
+	out.AzureAD = (*azure.AzureADConfig)(unsafe.Pointer(in.AzureAD))
+	out.ACRCredentialProvider = (*azure.ACRCredentialProviderConfig)(unsafe.Pointer(in.ACRCredentialProvider))
 	return nil
 }
 
@@ -308,6 +735,15 @@ func Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *Control
 
 func autoConvert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *azure.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.LoadBalancerSKU = (*LoadBalancerSKU)(unsafe.Pointer(in.LoadBalancerSKU))
+	out.CloudProviderConfig = (*CloudProviderConfig)(unsafe.Pointer(in.CloudProviderConfig))
+	out.VolumeSnapshots = (*VolumeSnapshotConfig)(unsafe.Pointer(in.VolumeSnapshots))
+	out.StorageClasses = (*StorageClasses)(unsafe.Pointer(in.StorageClasses))
+	out.KubeAPIServerExposure = (*KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.PrivateLinkService = (*PrivateLinkServiceConfig)(unsafe.Pointer(in.PrivateLinkService))
+	out.KMS = (*KMSConfig)(unsafe.Pointer(in.KMS))
+	out.AzureAD = (*AzureADConfig)(unsafe.Pointer(in.AzureAD))
+	out.ACRCredentialProvider = (*ACRCredentialProviderConfig)(unsafe.Pointer(in.ACRCredentialProvider))
 	return nil
 }
 
@@ -316,6 +752,50 @@ func Convert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *azure.C
 	return autoConvert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in *ControlPlaneStatus, out *azure.ControlPlaneStatus, s conversion.Scope) error {
+	out.PrivateLinkServiceAlias = (*string)(unsafe.Pointer(in.PrivateLinkServiceAlias))
+	return nil
+}
+
+// Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in *ControlPlaneStatus, out *azure.ControlPlaneStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in, out, s)
+}
+
+func autoConvert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in *azure.ControlPlaneStatus, out *ControlPlaneStatus, s conversion.Scope) error {
+	out.PrivateLinkServiceAlias = (*string)(unsafe.Pointer(in.PrivateLinkServiceAlias))
+	return nil
+}
+
+// Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus is an autogenerated conversion function.
+func Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in *azure.ControlPlaneStatus, out *ControlPlaneStatus, s conversion.Scope) error {
+	return autoConvert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_DedicatedHostGroup_To_azure_DedicatedHostGroup(in *DedicatedHostGroup, out *azure.DedicatedHostGroup, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	out.HostID = (*string)(unsafe.Pointer(in.HostID))
+	return nil
+}
+
+// Convert_v1alpha1_DedicatedHostGroup_To_azure_DedicatedHostGroup is an autogenerated conversion function.
+func Convert_v1alpha1_DedicatedHostGroup_To_azure_DedicatedHostGroup(in *DedicatedHostGroup, out *azure.DedicatedHostGroup, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DedicatedHostGroup_To_azure_DedicatedHostGroup(in, out, s)
+}
+
+func autoConvert_azure_DedicatedHostGroup_To_v1alpha1_DedicatedHostGroup(in *azure.DedicatedHostGroup, out *DedicatedHostGroup, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	out.HostID = (*string)(unsafe.Pointer(in.HostID))
+	return nil
+}
+
+// Convert_azure_DedicatedHostGroup_To_v1alpha1_DedicatedHostGroup is an autogenerated conversion function.
+func Convert_azure_DedicatedHostGroup_To_v1alpha1_DedicatedHostGroup(in *azure.DedicatedHostGroup, out *DedicatedHostGroup, s conversion.Scope) error {
+	return autoConvert_azure_DedicatedHostGroup_To_v1alpha1_DedicatedHostGroup(in, out, s)
+}
+
 func autoConvert_v1alpha1_DomainCount_To_azure_DomainCount(in *DomainCount, out *azure.DomainCount, s conversion.Scope) error {
 	out.Region = in.Region
 	out.Count = in.Count
@@ -344,6 +824,8 @@ func autoConvert_v1alpha1_InfrastructureConfig_To_azure_InfrastructureConfig(in
 		return err
 	}
 	out.Zoned = in.Zoned
+	out.ProximityPlacementGroups = *(*[]azure.ProximityPlacementGroup)(unsafe.Pointer(&in.ProximityPlacementGroups))
+	out.AvailabilitySetPerWorkerPool = in.AvailabilitySetPerWorkerPool
 	return nil
 }
 
@@ -358,6 +840,8 @@ func autoConvert_azure_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in
 		return err
 	}
 	out.Zoned = in.Zoned
+	out.ProximityPlacementGroups = *(*[]ProximityPlacementGroup)(unsafe.Pointer(&in.ProximityPlacementGroups))
+	out.AvailabilitySetPerWorkerPool = in.AvailabilitySetPerWorkerPool
 	return nil
 }
 
@@ -376,7 +860,9 @@ func autoConvert_v1alpha1_InfrastructureStatus_To_azure_InfrastructureStatus(in
 	out.AvailabilitySets = *(*[]azure.AvailabilitySet)(unsafe.Pointer(&in.AvailabilitySets))
 	out.RouteTables = *(*[]azure.RouteTable)(unsafe.Pointer(&in.RouteTables))
 	out.SecurityGroups = *(*[]azure.SecurityGroup)(unsafe.Pointer(&in.SecurityGroups))
+	out.ApplicationSecurityGroups = *(*[]azure.ApplicationSecurityGroupStatus)(unsafe.Pointer(&in.ApplicationSecurityGroups))
 	out.Zoned = in.Zoned
+	out.ProximityPlacementGroups = *(*[]azure.ProximityPlacementGroupStatus)(unsafe.Pointer(&in.ProximityPlacementGroups))
 	return nil
 }
 
@@ -395,7 +881,9 @@ func autoConvert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in
 	out.AvailabilitySets = *(*[]AvailabilitySet)(unsafe.Pointer(&in.AvailabilitySets))
 	out.RouteTables = *(*[]RouteTable)(unsafe.Pointer(&in.RouteTables))
 	out.SecurityGroups = *(*[]SecurityGroup)(unsafe.Pointer(&in.SecurityGroups))
+	out.ApplicationSecurityGroups = *(*[]ApplicationSecurityGroupStatus)(unsafe.Pointer(&in.ApplicationSecurityGroups))
 	out.Zoned = in.Zoned
+	out.ProximityPlacementGroups = *(*[]ProximityPlacementGroupStatus)(unsafe.Pointer(&in.ProximityPlacementGroups))
 	return nil
 }
 
@@ -404,6 +892,30 @@ func Convert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *azu
 	return autoConvert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_KMSConfig_To_azure_KMSConfig(in *KMSConfig, out *azure.KMSConfig, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	return nil
+}
+
+// Convert_v1alpha1_KMSConfig_To_azure_KMSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KMSConfig_To_azure_KMSConfig(in *KMSConfig, out *azure.KMSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMSConfig_To_azure_KMSConfig(in, out, s)
+}
+
+func autoConvert_azure_KMSConfig_To_v1alpha1_KMSConfig(in *azure.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	return nil
+}
+
+// Convert_azure_KMSConfig_To_v1alpha1_KMSConfig is an autogenerated conversion function.
+func Convert_azure_KMSConfig_To_v1alpha1_KMSConfig(in *azure.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	return autoConvert_azure_KMSConfig_To_v1alpha1_KMSConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineImage_To_azure_MachineImage(in *MachineImage, out *azure.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
@@ -477,7 +989,13 @@ func autoConvert_v1alpha1_NetworkConfig_To_azure_NetworkConfig(in *NetworkConfig
 		return err
 	}
 	out.Workers = in.Workers
+	out.IPv6Workers = (*string)(unsafe.Pointer(in.IPv6Workers))
 	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.ApplicationSecurityGroups = *(*[]azure.ApplicationSecurityGroup)(unsafe.Pointer(&in.ApplicationSecurityGroups))
+	out.SecurityRules = *(*[]azure.SecurityRule)(unsafe.Pointer(&in.SecurityRules))
+	out.Subnets = *(*[]azure.WorkerSubnet)(unsafe.Pointer(&in.Subnets))
+	out.Zones = *(*[]azure.Zone)(unsafe.Pointer(&in.Zones))
+	out.Routes = *(*[]azure.Route)(unsafe.Pointer(&in.Routes))
 	return nil
 }
 
@@ -491,7 +1009,13 @@ func autoConvert_azure_NetworkConfig_To_v1alpha1_NetworkConfig(in *azure.Network
 		return err
 	}
 	out.Workers = in.Workers
+	out.IPv6Workers = (*string)(unsafe.Pointer(in.IPv6Workers))
 	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.ApplicationSecurityGroups = *(*[]ApplicationSecurityGroup)(unsafe.Pointer(&in.ApplicationSecurityGroups))
+	out.SecurityRules = *(*[]SecurityRule)(unsafe.Pointer(&in.SecurityRules))
+	out.Subnets = *(*[]WorkerSubnet)(unsafe.Pointer(&in.Subnets))
+	out.Zones = *(*[]Zone)(unsafe.Pointer(&in.Zones))
+	out.Routes = *(*[]Route)(unsafe.Pointer(&in.Routes))
 	return nil
 }
 
@@ -526,6 +1050,98 @@ func Convert_azure_NetworkStatus_To_v1alpha1_NetworkStatus(in *azure.NetworkStat
 	return autoConvert_azure_NetworkStatus_To_v1alpha1_NetworkStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_PrivateDNSZoneLinkStatus_To_azure_PrivateDNSZoneLinkStatus(in *PrivateDNSZoneLinkStatus, out *azure.PrivateDNSZoneLinkStatus, s conversion.Scope) error {
+	out.PrivateDNSZoneID = in.PrivateDNSZoneID
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_PrivateDNSZoneLinkStatus_To_azure_PrivateDNSZoneLinkStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PrivateDNSZoneLinkStatus_To_azure_PrivateDNSZoneLinkStatus(in *PrivateDNSZoneLinkStatus, out *azure.PrivateDNSZoneLinkStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrivateDNSZoneLinkStatus_To_azure_PrivateDNSZoneLinkStatus(in, out, s)
+}
+
+func autoConvert_azure_PrivateDNSZoneLinkStatus_To_v1alpha1_PrivateDNSZoneLinkStatus(in *azure.PrivateDNSZoneLinkStatus, out *PrivateDNSZoneLinkStatus, s conversion.Scope) error {
+	out.PrivateDNSZoneID = in.PrivateDNSZoneID
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_azure_PrivateDNSZoneLinkStatus_To_v1alpha1_PrivateDNSZoneLinkStatus is an autogenerated conversion function.
+func Convert_azure_PrivateDNSZoneLinkStatus_To_v1alpha1_PrivateDNSZoneLinkStatus(in *azure.PrivateDNSZoneLinkStatus, out *PrivateDNSZoneLinkStatus, s conversion.Scope) error {
+	return autoConvert_azure_PrivateDNSZoneLinkStatus_To_v1alpha1_PrivateDNSZoneLinkStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrivateLinkServiceConfig_To_azure_PrivateLinkServiceConfig(in *PrivateLinkServiceConfig, out *azure.PrivateLinkServiceConfig, s conversion.Scope) error {
+	out.Visibility = *(*[]string)(unsafe.Pointer(&in.Visibility))
+	out.AutoApproval = *(*[]string)(unsafe.Pointer(&in.AutoApproval))
+	return nil
+}
+
+// Convert_v1alpha1_PrivateLinkServiceConfig_To_azure_PrivateLinkServiceConfig is an autogenerated conversion function.
+func Convert_v1alpha1_PrivateLinkServiceConfig_To_azure_PrivateLinkServiceConfig(in *PrivateLinkServiceConfig, out *azure.PrivateLinkServiceConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrivateLinkServiceConfig_To_azure_PrivateLinkServiceConfig(in, out, s)
+}
+
+func autoConvert_azure_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in *azure.PrivateLinkServiceConfig, out *PrivateLinkServiceConfig, s conversion.Scope) error {
+	out.Visibility = *(*[]string)(unsafe.Pointer(&in.Visibility))
+	out.AutoApproval = *(*[]string)(unsafe.Pointer(&in.AutoApproval))
+	return nil
+}
+
+// Convert_azure_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig is an autogenerated conversion function.
+func Convert_azure_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in *azure.PrivateLinkServiceConfig, out *PrivateLinkServiceConfig, s conversion.Scope) error {
+	return autoConvert_azure_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProximityPlacementGroup_To_azure_ProximityPlacementGroup(in *ProximityPlacementGroup, out *azure.ProximityPlacementGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	return nil
+}
+
+// Convert_v1alpha1_ProximityPlacementGroup_To_azure_ProximityPlacementGroup is an autogenerated conversion function.
+func Convert_v1alpha1_ProximityPlacementGroup_To_azure_ProximityPlacementGroup(in *ProximityPlacementGroup, out *azure.ProximityPlacementGroup, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProximityPlacementGroup_To_azure_ProximityPlacementGroup(in, out, s)
+}
+
+func autoConvert_azure_ProximityPlacementGroup_To_v1alpha1_ProximityPlacementGroup(in *azure.ProximityPlacementGroup, out *ProximityPlacementGroup, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	return nil
+}
+
+// Convert_azure_ProximityPlacementGroup_To_v1alpha1_ProximityPlacementGroup is an autogenerated conversion function.
+func Convert_azure_ProximityPlacementGroup_To_v1alpha1_ProximityPlacementGroup(in *azure.ProximityPlacementGroup, out *ProximityPlacementGroup, s conversion.Scope) error {
+	return autoConvert_azure_ProximityPlacementGroup_To_v1alpha1_ProximityPlacementGroup(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProximityPlacementGroupStatus_To_azure_ProximityPlacementGroupStatus(in *ProximityPlacementGroupStatus, out *azure.ProximityPlacementGroupStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.Purpose = azure.Purpose(in.Purpose)
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	return nil
+}
+
+// Convert_v1alpha1_ProximityPlacementGroupStatus_To_azure_ProximityPlacementGroupStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ProximityPlacementGroupStatus_To_azure_ProximityPlacementGroupStatus(in *ProximityPlacementGroupStatus, out *azure.ProximityPlacementGroupStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProximityPlacementGroupStatus_To_azure_ProximityPlacementGroupStatus(in, out, s)
+}
+
+func autoConvert_azure_ProximityPlacementGroupStatus_To_v1alpha1_ProximityPlacementGroupStatus(in *azure.ProximityPlacementGroupStatus, out *ProximityPlacementGroupStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.Purpose = Purpose(in.Purpose)
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	return nil
+}
+
+// Convert_azure_ProximityPlacementGroupStatus_To_v1alpha1_ProximityPlacementGroupStatus is an autogenerated conversion function.
+func Convert_azure_ProximityPlacementGroupStatus_To_v1alpha1_ProximityPlacementGroupStatus(in *azure.ProximityPlacementGroupStatus, out *ProximityPlacementGroupStatus, s conversion.Scope) error {
+	return autoConvert_azure_ProximityPlacementGroupStatus_To_v1alpha1_ProximityPlacementGroupStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_ResourceGroup_To_azure_ResourceGroup(in *ResourceGroup, out *azure.ResourceGroup, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -546,6 +1162,32 @@ func Convert_azure_ResourceGroup_To_v1alpha1_ResourceGroup(in *azure.ResourceGro
 	return autoConvert_azure_ResourceGroup_To_v1alpha1_ResourceGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_Route_To_azure_Route(in *Route, out *azure.Route, s conversion.Scope) error {
+	out.Name = in.Name
+	out.AddressPrefix = in.AddressPrefix
+	out.NextHopType = azure.RouteNextHopType(in.NextHopType)
+	out.NextHopIPAddress = (*string)(unsafe.Pointer(in.NextHopIPAddress))
+	return nil
+}
+
+// Convert_v1alpha1_Route_To_azure_Route is an autogenerated conversion function.
+func Convert_v1alpha1_Route_To_azure_Route(in *Route, out *azure.Route, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Route_To_azure_Route(in, out, s)
+}
+
+func autoConvert_azure_Route_To_v1alpha1_Route(in *azure.Route, out *Route, s conversion.Scope) error {
+	out.Name = in.Name
+	out.AddressPrefix = in.AddressPrefix
+	out.NextHopType = RouteNextHopType(in.NextHopType)
+	out.NextHopIPAddress = (*string)(unsafe.Pointer(in.NextHopIPAddress))
+	return nil
+}
+
+// Convert_azure_Route_To_v1alpha1_Route is an autogenerated conversion function.
+func Convert_azure_Route_To_v1alpha1_Route(in *azure.Route, out *Route, s conversion.Scope) error {
+	return autoConvert_azure_Route_To_v1alpha1_Route(in, out, s)
+}
+
 func autoConvert_v1alpha1_RouteTable_To_azure_RouteTable(in *RouteTable, out *azure.RouteTable, s conversion.Scope) error {
 	out.Purpose = azure.Purpose(in.Purpose)
 	out.Name = in.Name
@@ -590,9 +1232,103 @@ func Convert_azure_SecurityGroup_To_v1alpha1_SecurityGroup(in *azure.SecurityGro
 	return autoConvert_azure_SecurityGroup_To_v1alpha1_SecurityGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_SecurityRule_To_azure_SecurityRule(in *SecurityRule, out *azure.SecurityRule, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Priority = in.Priority
+	out.Direction = azure.SecurityRuleDirection(in.Direction)
+	out.Access = azure.SecurityRuleAccess(in.Access)
+	out.Protocol = azure.SecurityRuleProtocol(in.Protocol)
+	out.SourceAddressPrefix = (*string)(unsafe.Pointer(in.SourceAddressPrefix))
+	out.SourcePortRange = (*string)(unsafe.Pointer(in.SourcePortRange))
+	out.SourceApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.SourceApplicationSecurityGroups))
+	out.DestinationAddressPrefix = (*string)(unsafe.Pointer(in.DestinationAddressPrefix))
+	out.DestinationPortRange = (*string)(unsafe.Pointer(in.DestinationPortRange))
+	out.DestinationApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.DestinationApplicationSecurityGroups))
+	return nil
+}
+
+// Convert_v1alpha1_SecurityRule_To_azure_SecurityRule is an autogenerated conversion function.
+func Convert_v1alpha1_SecurityRule_To_azure_SecurityRule(in *SecurityRule, out *azure.SecurityRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecurityRule_To_azure_SecurityRule(in, out, s)
+}
+
+func autoConvert_azure_SecurityRule_To_v1alpha1_SecurityRule(in *azure.SecurityRule, out *SecurityRule, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Priority = in.Priority
+	out.Direction = SecurityRuleDirection(in.Direction)
+	out.Access = SecurityRuleAccess(in.Access)
+	out.Protocol = SecurityRuleProtocol(in.Protocol)
+	out.SourceAddressPrefix = (*string)(unsafe.Pointer(in.SourceAddressPrefix))
+	out.SourcePortRange = (*string)(unsafe.Pointer(in.SourcePortRange))
+	out.SourceApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.SourceApplicationSecurityGroups))
+	out.DestinationAddressPrefix = (*string)(unsafe.Pointer(in.DestinationAddressPrefix))
+	out.DestinationPortRange = (*string)(unsafe.Pointer(in.DestinationPortRange))
+	out.DestinationApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.DestinationApplicationSecurityGroups))
+	return nil
+}
+
+// Convert_azure_SecurityRule_To_v1alpha1_SecurityRule is an autogenerated conversion function.
+func Convert_azure_SecurityRule_To_v1alpha1_SecurityRule(in *azure.SecurityRule, out *SecurityRule, s conversion.Scope) error {
+	return autoConvert_azure_SecurityRule_To_v1alpha1_SecurityRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageClass_To_azure_StorageClass(in *StorageClass, out *azure.StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SKU = in.SKU
+	out.Default = (*bool)(unsafe.Pointer(in.Default))
+	out.VolumeBindingMode = (*string)(unsafe.Pointer(in.VolumeBindingMode))
+	out.ReclaimPolicy = (*string)(unsafe.Pointer(in.ReclaimPolicy))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	return nil
+}
+
+// Convert_v1alpha1_StorageClass_To_azure_StorageClass is an autogenerated conversion function.
+func Convert_v1alpha1_StorageClass_To_azure_StorageClass(in *StorageClass, out *azure.StorageClass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageClass_To_azure_StorageClass(in, out, s)
+}
+
+func autoConvert_azure_StorageClass_To_v1alpha1_StorageClass(in *azure.StorageClass, out *StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SKU = in.SKU
+	out.Default = (*bool)(unsafe.Pointer(in.Default))
+	out.VolumeBindingMode = (*string)(unsafe.Pointer(in.VolumeBindingMode))
+	out.ReclaimPolicy = (*string)(unsafe.Pointer(in.ReclaimPolicy))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	return nil
+}
+
+// Convert_azure_StorageClass_To_v1alpha1_StorageClass is an autogenerated conversion function.
+func Convert_azure_StorageClass_To_v1alpha1_StorageClass(in *azure.StorageClass, out *StorageClass, s conversion.Scope) error {
+	return autoConvert_azure_StorageClass_To_v1alpha1_StorageClass(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageClasses_To_azure_StorageClasses(in *StorageClasses, out *azure.StorageClasses, s conversion.Scope) error {
+	out.Mode = (*azure.StorageClassesMode)(unsafe.Pointer(in.Mode))
+	out.Classes = *(*[]azure.StorageClass)(unsafe.Pointer(&in.Classes))
+	return nil
+}
+
+// Convert_v1alpha1_StorageClasses_To_azure_StorageClasses is an autogenerated conversion function.
+func Convert_v1alpha1_StorageClasses_To_azure_StorageClasses(in *StorageClasses, out *azure.StorageClasses, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageClasses_To_azure_StorageClasses(in, out, s)
+}
+
+func autoConvert_azure_StorageClasses_To_v1alpha1_StorageClasses(in *azure.StorageClasses, out *StorageClasses, s conversion.Scope) error {
+	out.Mode = (*StorageClassesMode)(unsafe.Pointer(in.Mode))
+	out.Classes = *(*[]StorageClass)(unsafe.Pointer(&in.Classes))
+	return nil
+}
+
+// Convert_azure_StorageClasses_To_v1alpha1_StorageClasses is an autogenerated conversion function.
+func Convert_azure_StorageClasses_To_v1alpha1_StorageClasses(in *azure.StorageClasses, out *StorageClasses, s conversion.Scope) error {
+	return autoConvert_azure_StorageClasses_To_v1alpha1_StorageClasses(in, out, s)
+}
+
 func autoConvert_v1alpha1_Subnet_To_azure_Subnet(in *Subnet, out *azure.Subnet, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Purpose = azure.Purpose(in.Purpose)
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	out.DualStack = in.DualStack
 	return nil
 }
 
@@ -604,6 +1340,8 @@ func Convert_v1alpha1_Subnet_To_azure_Subnet(in *Subnet, out *azure.Subnet, s co
 func autoConvert_azure_Subnet_To_v1alpha1_Subnet(in *azure.Subnet, out *Subnet, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Purpose = Purpose(in.Purpose)
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
+	out.DualStack = in.DualStack
 	return nil
 }
 
@@ -616,6 +1354,10 @@ func autoConvert_v1alpha1_VNet_To_azure_VNet(in *VNet, out *azure.VNet, s conver
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
 	out.CIDR = (*string)(unsafe.Pointer(in.CIDR))
+	out.IPv6CIDR = (*string)(unsafe.Pointer(in.IPv6CIDR))
+	out.Peerings = *(*[]azure.VNetPeering)(unsafe.Pointer(&in.Peerings))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.PrivateDNSZoneIDs = *(*[]string)(unsafe.Pointer(&in.PrivateDNSZoneIDs))
 	return nil
 }
 
@@ -628,6 +1370,10 @@ func autoConvert_azure_VNet_To_v1alpha1_VNet(in *azure.VNet, out *VNet, s conver
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
 	out.CIDR = (*string)(unsafe.Pointer(in.CIDR))
+	out.IPv6CIDR = (*string)(unsafe.Pointer(in.IPv6CIDR))
+	out.Peerings = *(*[]VNetPeering)(unsafe.Pointer(&in.Peerings))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.PrivateDNSZoneIDs = *(*[]string)(unsafe.Pointer(&in.PrivateDNSZoneIDs))
 	return nil
 }
 
@@ -636,9 +1382,63 @@ func Convert_azure_VNet_To_v1alpha1_VNet(in *azure.VNet, out *VNet, s conversion
 	return autoConvert_azure_VNet_To_v1alpha1_VNet(in, out, s)
 }
 
+func autoConvert_v1alpha1_VNetPeering_To_azure_VNetPeering(in *VNetPeering, out *azure.VNetPeering, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RemoteVNetID = in.RemoteVNetID
+	out.AllowForwardedTraffic = in.AllowForwardedTraffic
+	out.UseRemoteGateways = in.UseRemoteGateways
+	out.RemoteAddressSpaces = *(*[]string)(unsafe.Pointer(&in.RemoteAddressSpaces))
+	return nil
+}
+
+// Convert_v1alpha1_VNetPeering_To_azure_VNetPeering is an autogenerated conversion function.
+func Convert_v1alpha1_VNetPeering_To_azure_VNetPeering(in *VNetPeering, out *azure.VNetPeering, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VNetPeering_To_azure_VNetPeering(in, out, s)
+}
+
+func autoConvert_azure_VNetPeering_To_v1alpha1_VNetPeering(in *azure.VNetPeering, out *VNetPeering, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RemoteVNetID = in.RemoteVNetID
+	out.AllowForwardedTraffic = in.AllowForwardedTraffic
+	out.UseRemoteGateways = in.UseRemoteGateways
+	out.RemoteAddressSpaces = *(*[]string)(unsafe.Pointer(&in.RemoteAddressSpaces))
+	return nil
+}
+
+// Convert_azure_VNetPeering_To_v1alpha1_VNetPeering is an autogenerated conversion function.
+func Convert_azure_VNetPeering_To_v1alpha1_VNetPeering(in *azure.VNetPeering, out *VNetPeering, s conversion.Scope) error {
+	return autoConvert_azure_VNetPeering_To_v1alpha1_VNetPeering(in, out, s)
+}
+
+func autoConvert_v1alpha1_VNetPeeringStatus_To_azure_VNetPeeringStatus(in *VNetPeeringStatus, out *azure.VNetPeeringStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.State = in.State
+	return nil
+}
+
+// Convert_v1alpha1_VNetPeeringStatus_To_azure_VNetPeeringStatus is an autogenerated conversion function.
+func Convert_v1alpha1_VNetPeeringStatus_To_azure_VNetPeeringStatus(in *VNetPeeringStatus, out *azure.VNetPeeringStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VNetPeeringStatus_To_azure_VNetPeeringStatus(in, out, s)
+}
+
+func autoConvert_azure_VNetPeeringStatus_To_v1alpha1_VNetPeeringStatus(in *azure.VNetPeeringStatus, out *VNetPeeringStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.State = in.State
+	return nil
+}
+
+// Convert_azure_VNetPeeringStatus_To_v1alpha1_VNetPeeringStatus is an autogenerated conversion function.
+func Convert_azure_VNetPeeringStatus_To_v1alpha1_VNetPeeringStatus(in *azure.VNetPeeringStatus, out *VNetPeeringStatus, s conversion.Scope) error {
+	return autoConvert_azure_VNetPeeringStatus_To_v1alpha1_VNetPeeringStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_VNetStatus_To_azure_VNetStatus(in *VNetStatus, out *azure.VNetStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	out.Peerings = *(*[]azure.VNetPeeringStatus)(unsafe.Pointer(&in.Peerings))
+	out.PrivateDNSZoneLinks = *(*[]azure.PrivateDNSZoneLinkStatus)(unsafe.Pointer(&in.PrivateDNSZoneLinks))
 	return nil
 }
 
@@ -650,6 +1450,8 @@ func Convert_v1alpha1_VNetStatus_To_azure_VNetStatus(in *VNetStatus, out *azure.
 func autoConvert_azure_VNetStatus_To_v1alpha1_VNetStatus(in *azure.VNetStatus, out *VNetStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	out.Peerings = *(*[]VNetPeeringStatus)(unsafe.Pointer(&in.Peerings))
+	out.PrivateDNSZoneLinks = *(*[]PrivateDNSZoneLinkStatus)(unsafe.Pointer(&in.PrivateDNSZoneLinks))
 	return nil
 }
 
@@ -658,6 +1460,52 @@ func Convert_azure_VNetStatus_To_v1alpha1_VNetStatus(in *azure.VNetStatus, out *
 	return autoConvert_azure_VNetStatus_To_v1alpha1_VNetStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_VolumeSnapshotConfig_To_azure_VolumeSnapshotConfig(in *VolumeSnapshotConfig, out *azure.VolumeSnapshotConfig, s conversion.Scope) error {
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	return nil
+}
+
+// Convert_v1alpha1_VolumeSnapshotConfig_To_azure_VolumeSnapshotConfig is an autogenerated conversion function.
+func Convert_v1alpha1_VolumeSnapshotConfig_To_azure_VolumeSnapshotConfig(in *VolumeSnapshotConfig, out *azure.VolumeSnapshotConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VolumeSnapshotConfig_To_azure_VolumeSnapshotConfig(in, out, s)
+}
+
+func autoConvert_azure_VolumeSnapshotConfig_To_v1alpha1_VolumeSnapshotConfig(in *azure.VolumeSnapshotConfig, out *VolumeSnapshotConfig, s conversion.Scope) error {
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	return nil
+}
+
+// Convert_azure_VolumeSnapshotConfig_To_v1alpha1_VolumeSnapshotConfig is an autogenerated conversion function.
+func Convert_azure_VolumeSnapshotConfig_To_v1alpha1_VolumeSnapshotConfig(in *azure.VolumeSnapshotConfig, out *VolumeSnapshotConfig, s conversion.Scope) error {
+	return autoConvert_azure_VolumeSnapshotConfig_To_v1alpha1_VolumeSnapshotConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_WorkerConfig_To_azure_WorkerConfig(in *WorkerConfig, out *azure.WorkerConfig, s conversion.Scope) error {
+	out.ApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.ApplicationSecurityGroups))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.ProximityPlacementGroup = (*string)(unsafe.Pointer(in.ProximityPlacementGroup))
+	out.DedicatedHostGroup = (*azure.DedicatedHostGroup)(unsafe.Pointer(in.DedicatedHostGroup))
+	return nil
+}
+
+// Convert_v1alpha1_WorkerConfig_To_azure_WorkerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_WorkerConfig_To_azure_WorkerConfig(in *WorkerConfig, out *azure.WorkerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkerConfig_To_azure_WorkerConfig(in, out, s)
+}
+
+func autoConvert_azure_WorkerConfig_To_v1alpha1_WorkerConfig(in *azure.WorkerConfig, out *WorkerConfig, s conversion.Scope) error {
+	out.ApplicationSecurityGroups = *(*[]string)(unsafe.Pointer(&in.ApplicationSecurityGroups))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.ProximityPlacementGroup = (*string)(unsafe.Pointer(in.ProximityPlacementGroup))
+	out.DedicatedHostGroup = (*DedicatedHostGroup)(unsafe.Pointer(in.DedicatedHostGroup))
+	return nil
+}
+
+// Convert_azure_WorkerConfig_To_v1alpha1_WorkerConfig is an autogenerated conversion function.
+func Convert_azure_WorkerConfig_To_v1alpha1_WorkerConfig(in *azure.WorkerConfig, out *WorkerConfig, s conversion.Scope) error {
+	return autoConvert_azure_WorkerConfig_To_v1alpha1_WorkerConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerStatus_To_azure_WorkerStatus(in *WorkerStatus, out *azure.WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]azure.MachineImage)(unsafe.Pointer(&in.MachineImages))
 	return nil
@@ -677,3 +1525,51 @@ func autoConvert_azure_WorkerStatus_To_v1alpha1_WorkerStatus(in *azure.WorkerSta
 func Convert_azure_WorkerStatus_To_v1alpha1_WorkerStatus(in *azure.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	return autoConvert_azure_WorkerStatus_To_v1alpha1_WorkerStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_WorkerSubnet_To_azure_WorkerSubnet(in *WorkerSubnet, out *azure.WorkerSubnet, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CIDR = in.CIDR
+	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.SecurityGroup = (*string)(unsafe.Pointer(in.SecurityGroup))
+	return nil
+}
+
+// Convert_v1alpha1_WorkerSubnet_To_azure_WorkerSubnet is an autogenerated conversion function.
+func Convert_v1alpha1_WorkerSubnet_To_azure_WorkerSubnet(in *WorkerSubnet, out *azure.WorkerSubnet, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkerSubnet_To_azure_WorkerSubnet(in, out, s)
+}
+
+func autoConvert_azure_WorkerSubnet_To_v1alpha1_WorkerSubnet(in *azure.WorkerSubnet, out *WorkerSubnet, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CIDR = in.CIDR
+	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.SecurityGroup = (*string)(unsafe.Pointer(in.SecurityGroup))
+	return nil
+}
+
+// Convert_azure_WorkerSubnet_To_v1alpha1_WorkerSubnet is an autogenerated conversion function.
+func Convert_azure_WorkerSubnet_To_v1alpha1_WorkerSubnet(in *azure.WorkerSubnet, out *WorkerSubnet, s conversion.Scope) error {
+	return autoConvert_azure_WorkerSubnet_To_v1alpha1_WorkerSubnet(in, out, s)
+}
+
+func autoConvert_v1alpha1_Zone_To_azure_Zone(in *Zone, out *azure.Zone, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CIDR = in.CIDR
+	return nil
+}
+
+// Convert_v1alpha1_Zone_To_azure_Zone is an autogenerated conversion function.
+func Convert_v1alpha1_Zone_To_azure_Zone(in *Zone, out *azure.Zone, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Zone_To_azure_Zone(in, out, s)
+}
+
+func autoConvert_azure_Zone_To_v1alpha1_Zone(in *azure.Zone, out *Zone, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CIDR = in.CIDR
+	return nil
+}
+
+// Convert_azure_Zone_To_v1alpha1_Zone is an autogenerated conversion function.
+func Convert_azure_Zone_To_v1alpha1_Zone(in *azure.Zone, out *Zone, s conversion.Scope) error {
+	return autoConvert_azure_Zone_To_v1alpha1_Zone(in, out, s)
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	availableLoadBalancerSKUs       = []string{string(apisazure.LoadBalancerSKUBasic), string(apisazure.LoadBalancerSKUStandard)}
+	availableKubeAPIServerExposures = []string{string(apisazure.KubeAPIServerExposurePublic), string(apisazure.KubeAPIServerExposurePrivate)}
+
+	// resourceGroupNameRegex matches the names of Azure resource groups. They consist of up to 90 alphanumerics, underscores,
+	// parentheses, hyphens and periods and must not end with a period.
+	resourceGroupNameRegex = regexp.MustCompile(`^[-\w.()]{0,89}[-\w()]$`)
+	// guidRegex matches GUIDs like the IDs of Azure subscriptions, tenants and applications.
+	guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// keyVaultNameRegex matches the names of Azure Key Vaults. They consist of 3 to 24 alphanumerics and hyphens, start
+	// with a letter and end with a letter or digit.
+	keyVaultNameRegex = regexp.MustCompile(`^[a-zA-Z][-a-zA-Z0-9]{1,22}[a-zA-Z0-9]$`)
+	// keyNameRegex matches the names of keys in Azure Key Vaults.
+	keyNameRegex = regexp.MustCompile(`^[-a-zA-Z0-9]{1,127}$`)
+	// keyVersionRegex matches the versions of keys in Azure Key Vaults.
+	keyVersionRegex = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+	// managedIdentityIDRegex matches the resource IDs of user-assigned managed identities.
+	managedIdentityIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.ManagedIdentity/userAssignedIdentities/[^/]+$`)
+
+	availableStorageClassesModes = []string{string(apisazure.StorageClassesModeExtend), string(apisazure.StorageClassesModeReplace)}
+	availableDiskSKUs            = []string{"Standard_LRS", "StandardSSD_LRS", "Premium_LRS", "UltraSSD_LRS", "StandardSSD_ZRS", "Premium_ZRS"}
+	availableVolumeBindingModes  = []string{string(storagev1.VolumeBindingImmediate), string(storagev1.VolumeBindingWaitForFirstConsumer)}
+	availableReclaimPolicies     = []string{string(corev1.PersistentVolumeReclaimDelete), string(corev1.PersistentVolumeReclaimRetain)}
+
+	// zoneRedundantDiskSKUs are only supported by the Azure Disk CSI driver.
+	zoneRedundantDiskSKUs = sets.NewString("StandardSSD_ZRS", "Premium_ZRS")
+	// reservedStorageClassParameters are set from the dedicated fields of a storage class.
+	reservedStorageClassParameters = sets.NewString("skuname", "storageaccounttype")
+)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisazure.ControlPlaneConfig, version string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sku := controlPlaneConfig.LoadBalancerSKU; sku != nil && *sku != apisazure.LoadBalancerSKUBasic && *sku != apisazure.LoadBalancerSKUStandard {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("loadBalancerSKU"), *sku, availableLoadBalancerSKUs))
+	}
+
+	if controlPlaneConfig.CloudProviderConfig != nil {
+		allErrs = append(allErrs, validateCloudProviderConfig(controlPlaneConfig.CloudProviderConfig, field.NewPath("cloudProviderConfig"))...)
+	}
+
+	if snapshots := controlPlaneConfig.VolumeSnapshots; snapshots != nil && snapshots.ResourceGroup != nil && !resourceGroupNameRegex.MatchString(*snapshots.ResourceGroup) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("volumeSnapshots", "resourceGroup"), *snapshots.ResourceGroup, "must be a valid resource group name"))
+	}
+
+	if exposure := controlPlaneConfig.KubeAPIServerExposure; exposure != nil && *exposure != apisazure.KubeAPIServerExposurePublic && *exposure != apisazure.KubeAPIServerExposurePrivate {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("kubeAPIServerExposure"), *exposure, availableKubeAPIServerExposures))
+	}
+
+	if controlPlaneConfig.PrivateLinkService != nil {
+		allErrs = append(allErrs, validatePrivateLinkService(controlPlaneConfig.PrivateLinkService, field.NewPath("privateLinkService"))...)
+
+		if exposure := controlPlaneConfig.KubeAPIServerExposure; exposure != nil && *exposure == apisazure.KubeAPIServerExposurePublic {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("privateLinkService"), "a private link service requires the kube-apiserver to be exposed privately"))
+		}
+	}
+
+	if controlPlaneConfig.StorageClasses != nil {
+		allErrs = append(allErrs, validateStorageClasses(controlPlaneConfig.StorageClasses, version, field.NewPath("storageClasses"))...)
+	}
+
+	if controlPlaneConfig.KMS != nil {
+		allErrs = append(allErrs, validateKMS(controlPlaneConfig.KMS, field.NewPath("kms"))...)
+	}
+
+	if controlPlaneConfig.AzureAD != nil {
+		allErrs = append(allErrs, validateAzureAD(controlPlaneConfig.AzureAD, field.NewPath("azureAD"))...)
+	}
+
+	if controlPlaneConfig.ACRCredentialProvider != nil {
+		allErrs = append(allErrs, validateACRCredentialProvider(controlPlaneConfig.ACRCredentialProvider, version, field.NewPath("acrCredentialProvider"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateControlPlaneConfigUpdate validates a ControlPlaneConfig object before an update.
+func ValidateControlPlaneConfigUpdate(oldConfig, newConfig *apisazure.ControlPlaneConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Clusters which have been migrated to standard load balancers cannot be migrated back.
+	if oldConfig.LoadBalancerSKU != nil && *oldConfig.LoadBalancerSKU == apisazure.LoadBalancerSKUStandard &&
+		(newConfig.LoadBalancerSKU == nil || *newConfig.LoadBalancerSKU != apisazure.LoadBalancerSKUStandard) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("loadBalancerSKU"), "standard load balancers cannot be migrated to basic load balancers"))
+	}
+
+	allErrs = append(allErrs, validateKMSUpdate(oldConfig.KMS, newConfig.KMS, field.NewPath("kms"))...)
+
+	return allErrs
+}
+
+func validateKMS(kms *apisazure.KMSConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !keyVaultNameRegex.MatchString(kms.KeyVaultName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyVaultName"), kms.KeyVaultName, "must be a valid key vault name"))
+	}
+	if !keyNameRegex.MatchString(kms.KeyName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyName"), kms.KeyName, "must be a valid key name"))
+	}
+	if !keyVersionRegex.MatchString(kms.KeyVersion) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyVersion"), kms.KeyVersion, "must be a valid key version"))
+	}
+
+	return allErrs
+}
+
+func validateAzureAD(azureAD *apisazure.AzureADConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !guidRegex.MatchString(azureAD.TenantID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tenantID"), azureAD.TenantID, "must be a valid tenant ID"))
+	}
+	if !guidRegex.MatchString(azureAD.ClientID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clientID"), azureAD.ClientID, "must be a valid client ID"))
+	}
+	if azureAD.UsernameClaim != nil && len(*azureAD.UsernameClaim) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("usernameClaim"), *azureAD.UsernameClaim, "must not be empty"))
+	}
+	if azureAD.GroupsClaim != nil && len(*azureAD.GroupsClaim) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("groupsClaim"), *azureAD.GroupsClaim, "must not be empty"))
+	}
+
+	return allErrs
+}
+
+func validateACRCredentialProvider(provider *apisazure.ACRCredentialProviderConfig, version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The kubelets support external image credential providers as of Kubernetes 1.20.
+	supported, err := versionutils.CompareVersions(version, ">=", "1.20")
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if !supported {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "the image credential provider requires Kubernetes version >= 1.20"))
+	}
+
+	if len(provider.Registries) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("registries"), "must provide at least one registry"))
+	}
+	registries := sets.NewString()
+	for i, registry := range provider.Registries {
+		idxPath := fldPath.Child("registries").Index(i)
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimPrefix(registry, "*.")) {
+			allErrs = append(allErrs, field.Invalid(idxPath, registry, msg))
+		}
+		if registries.Has(registry) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, registry))
+		}
+		registries.Insert(registry)
+	}
+
+	// The credentials of the cloud provider must not be written to the nodes, hence, the nodes authenticate with a
+	// managed identity.
+	if len(provider.ManagedIdentityClientID) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("managedIdentityClientID"), "must provide the client ID of the managed identity of the nodes"))
+	} else if !guidRegex.MatchString(provider.ManagedIdentityClientID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("managedIdentityClientID"), provider.ManagedIdentityClientID, "must be a valid client ID"))
+	}
+	if len(provider.ManagedIdentityID) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("managedIdentityID"), "must provide the resource ID of the managed identity of the nodes"))
+	} else if !managedIdentityIDRegex.MatchString(provider.ManagedIdentityID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("managedIdentityID"), provider.ManagedIdentityID, "must be a valid managed identity ID"))
+	}
+
+	return allErrs
+}
+
+func validateKMSUpdate(oldKMS, newKMS *apisazure.KMSConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if oldKMS == nil {
+		return allErrs
+	}
+
+	// Secrets which have been encrypted with the key cannot be decrypted anymore without it.
+	if newKMS == nil {
+		return append(allErrs, field.Forbidden(fldPath, "the encryption with a key vault key cannot be disabled"))
+	}
+	if newKMS.KeyVaultName != oldKMS.KeyVaultName {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("keyVaultName"), "field is immutable"))
+	}
+	if newKMS.KeyName != oldKMS.KeyName {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("keyName"), "field is immutable"))
+	}
+
+	return allErrs
+}
+
+func validateCloudProviderConfig(config *apisazure.CloudProviderConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit := config.RateLimit; rateLimit != nil {
+		rateLimitPath := fldPath.Child("rateLimit")
+		allErrs = append(allErrs, validatePositiveFloat(rateLimit.QPS, rateLimitPath.Child("qps"))...)
+		allErrs = append(allErrs, validatePositiveInt(rateLimit.Bucket, rateLimitPath.Child("bucket"))...)
+		allErrs = append(allErrs, validatePositiveFloat(rateLimit.QPSWrite, rateLimitPath.Child("qpsWrite"))...)
+		allErrs = append(allErrs, validatePositiveInt(rateLimit.BucketWrite, rateLimitPath.Child("bucketWrite"))...)
+	}
+
+	if backoff := config.Backoff; backoff != nil {
+		backoffPath := fldPath.Child("backoff")
+		if backoff.Retries != nil && *backoff.Retries < 0 {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("retries"), *backoff.Retries, "must not be negative"))
+		}
+		if backoff.Exponent != nil && *backoff.Exponent < 1 {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("exponent"), *backoff.Exponent, "must be at least 1"))
+		}
+		allErrs = append(allErrs, validatePositiveInt(backoff.Duration, backoffPath.Child("duration"))...)
+		if backoff.Jitter != nil && *backoff.Jitter < 0 {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("jitter"), *backoff.Jitter, "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePositiveFloat(value *float64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value != nil && *value <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, *value, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePositiveInt(value *int32, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value != nil && *value <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, *value, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePrivateLinkService(privateLinkService *apisazure.PrivateLinkServiceConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	visibleToAll := false
+	for i, subscription := range privateLinkService.Visibility {
+		if subscription == "*" {
+			visibleToAll = true
+			continue
+		}
+		if !guidRegex.MatchString(subscription) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("visibility").Index(i), subscription, "must be a subscription ID or '*'"))
+		}
+	}
+
+	visibility := sets.NewString(privateLinkService.Visibility...)
+	for i, subscription := range privateLinkService.AutoApproval {
+		idxPath := fldPath.Child("autoApproval").Index(i)
+
+		if !guidRegex.MatchString(subscription) {
+			allErrs = append(allErrs, field.Invalid(idxPath, subscription, "must be a subscription ID"))
+		} else if !visibleToAll && !visibility.Has(subscription) {
+			allErrs = append(allErrs, field.Forbidden(idxPath, "the private link service must be visible to automatically approved subscriptions"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateStorageClasses(storageClasses *apisazure.StorageClasses, version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if mode := storageClasses.Mode; mode != nil && *mode != apisazure.StorageClassesModeExtend && *mode != apisazure.StorageClassesModeReplace {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), *mode, availableStorageClassesModes))
+	}
+
+	csiEnabled, err := internal.IsCSIMigrationEnabled(version)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	var (
+		names        = sets.NewString()
+		defaultFound bool
+	)
+
+	for i, storageClass := range storageClasses.Classes {
+		idxPath := fldPath.Child("classes").Index(i)
+
+		if len(storageClass.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(storageClass.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), storageClass.Name, msg))
+			}
+			if names.Has(storageClass.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), storageClass.Name))
+			}
+			names.Insert(storageClass.Name)
+		}
+
+		if !sets.NewString(availableDiskSKUs...).Has(storageClass.SKU) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("sku"), storageClass.SKU, availableDiskSKUs))
+		} else if zoneRedundantDiskSKUs.Has(storageClass.SKU) && !csiEnabled {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("sku"), "zone-redundant disks are only supported for Kubernetes versions >= 1.21"))
+		}
+
+		if storageClass.Default != nil && *storageClass.Default {
+			if defaultFound {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("default"), "only one storage class can be the default storage class"))
+			}
+			defaultFound = true
+		}
+
+		if mode := storageClass.VolumeBindingMode; mode != nil && !sets.NewString(availableVolumeBindingModes...).Has(*mode) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("volumeBindingMode"), *mode, availableVolumeBindingModes))
+		}
+
+		if policy := storageClass.ReclaimPolicy; policy != nil && !sets.NewString(availableReclaimPolicies...).Has(*policy) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("reclaimPolicy"), *policy, availableReclaimPolicies))
+		}
+
+		for key := range storageClass.Parameters {
+			if reservedStorageClassParameters.Has(strings.ToLower(key)) {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("parameters").Key(key), "the disk SKU must be configured with the sku field"))
+			}
+		}
+	}
+
+	return allErrs
+}
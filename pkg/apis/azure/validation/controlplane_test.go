@@ -0,0 +1,408 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	. "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	. "github.com/gardener/gardener/pkg/utils/validation/gomega"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var (
+		basic    = apisazure.LoadBalancerSKUBasic
+		standard = apisazure.LoadBalancerSKUStandard
+
+		version = "1.21.0"
+
+		controlPlaneConfig *apisazure.ControlPlaneConfig
+	)
+
+	BeforeEach(func() {
+		controlPlaneConfig = &apisazure.ControlPlaneConfig{}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should pass for an empty configuration", func() {
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should pass for the standard load balancer sku", func() {
+			controlPlaneConfig.LoadBalancerSKU = &standard
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid unsupported load balancer skus", func() {
+			sku := apisazure.LoadBalancerSKU("premium")
+			controlPlaneConfig.LoadBalancerSKU = &sku
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("loadBalancerSKU"),
+			}))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig kubeAPIServerExposure", func() {
+		It("should pass for the private kube-apiserver exposure", func() {
+			private := apisazure.KubeAPIServerExposurePrivate
+			controlPlaneConfig.KubeAPIServerExposure = &private
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid unsupported kube-apiserver exposures", func() {
+			exposure := apisazure.KubeAPIServerExposure("internal")
+			controlPlaneConfig.KubeAPIServerExposure = &exposure
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("kubeAPIServerExposure"),
+			}))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig privateLinkService", func() {
+		const (
+			subscription1 = "00000000-1111-2222-3333-444444444444"
+			subscription2 = "55555555-6666-7777-8888-999999999999"
+		)
+
+		It("should pass for a valid private link service", func() {
+			controlPlaneConfig.PrivateLinkService = &apisazure.PrivateLinkServiceConfig{
+				Visibility:   []string{subscription1, subscription2},
+				AutoApproval: []string{subscription2},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should allow automatically approving subscriptions if the private link service is visible to all", func() {
+			controlPlaneConfig.PrivateLinkService = &apisazure.PrivateLinkServiceConfig{
+				Visibility:   []string{"*"},
+				AutoApproval: []string{subscription1},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid private link services", func() {
+			public := apisazure.KubeAPIServerExposurePublic
+			controlPlaneConfig.KubeAPIServerExposure = &public
+			controlPlaneConfig.PrivateLinkService = &apisazure.PrivateLinkServiceConfig{
+				Visibility:   []string{subscription1, "my-subscription"},
+				AutoApproval: []string{"*", subscription2},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("privateLinkService.visibility[1]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("privateLinkService.autoApproval[0]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("privateLinkService.autoApproval[1]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("privateLinkService")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig kms", func() {
+		It("should pass for a valid key vault key", func() {
+			controlPlaneConfig.KMS = &apisazure.KMSConfig{
+				KeyVaultName: "my-vault",
+				KeyName:      "etcd-encryption",
+				KeyVersion:   "0123456789abcdef0123456789abcdef",
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid key vault keys", func() {
+			controlPlaneConfig.KMS = &apisazure.KMSConfig{
+				KeyVaultName: "my_vault",
+				KeyName:      "",
+				KeyVersion:   "latest",
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("kms.keyVaultName")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("kms.keyName")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("kms.keyVersion")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig azureAD", func() {
+		It("should pass for a valid azure active directory configuration", func() {
+			controlPlaneConfig.AzureAD = &apisazure.AzureADConfig{
+				TenantID:     "00000000-1111-2222-3333-444444444444",
+				ClientID:     "55555555-6666-7777-8888-999999999999",
+				GroupsClaim:  util.StringPtr("roles"),
+				GroupsPrefix: util.StringPtr("aad:"),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid azure active directory configurations", func() {
+			controlPlaneConfig.AzureAD = &apisazure.AzureADConfig{
+				TenantID:      "my-tenant",
+				ClientID:      "",
+				UsernameClaim: util.StringPtr(""),
+				GroupsClaim:   util.StringPtr(""),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("azureAD.tenantID")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("azureAD.clientID")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("azureAD.usernameClaim")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("azureAD.groupsClaim")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig acrCredentialProvider", func() {
+		It("should pass for a valid image credential provider", func() {
+			controlPlaneConfig.ACRCredentialProvider = &apisazure.ACRCredentialProviderConfig{
+				Registries:              []string{"myregistry.azurecr.io", "*.azurecr.io"},
+				ManagedIdentityClientID: "00000000-1111-2222-3333-444444444444",
+				ManagedIdentityID:       "/subscriptions/sub/resourceGroups/identities/providers/Microsoft.ManagedIdentity/userAssignedIdentities/nodes",
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid image credential providers", func() {
+			controlPlaneConfig.ACRCredentialProvider = &apisazure.ACRCredentialProviderConfig{
+				Registries:              []string{"myregistry.azurecr.io", "my_registry", "myregistry.azurecr.io"},
+				ManagedIdentityClientID: "my-identity",
+				ManagedIdentityID:       "/subscriptions/sub/resourceGroups/identities/providers/Microsoft.Compute/virtualMachines/nodes",
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("acrCredentialProvider.registries[1]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeDuplicate), "Field": Equal("acrCredentialProvider.registries[2]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("acrCredentialProvider.managedIdentityClientID")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("acrCredentialProvider.managedIdentityID")})),
+			))
+		})
+
+		It("should forbid the image credential provider without registries and managed identity and for Kubernetes versions < 1.20", func() {
+			controlPlaneConfig.ACRCredentialProvider = &apisazure.ACRCredentialProviderConfig{}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, "1.19.8")).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("acrCredentialProvider")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("acrCredentialProvider.registries")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("acrCredentialProvider.managedIdentityClientID")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("acrCredentialProvider.managedIdentityID")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig cloudProviderConfig", func() {
+		It("should pass for valid rate limit and backoff settings", func() {
+			qps, bucket, retries, exponent := 20.0, int32(200), int32(0), 2.0
+			controlPlaneConfig.CloudProviderConfig = &apisazure.CloudProviderConfig{
+				RateLimit: &apisazure.CloudProviderRateLimit{QPS: &qps, Bucket: &bucket},
+				Backoff:   &apisazure.CloudProviderBackoff{Retries: &retries, Exponent: &exponent},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid rate limit and backoff settings", func() {
+			qps, bucketWrite, retries, exponent, duration, jitter := 0.0, int32(-1), int32(-1), 0.5, int32(0), -1.0
+			controlPlaneConfig.CloudProviderConfig = &apisazure.CloudProviderConfig{
+				RateLimit: &apisazure.CloudProviderRateLimit{QPS: &qps, BucketWrite: &bucketWrite},
+				Backoff:   &apisazure.CloudProviderBackoff{Retries: &retries, Exponent: &exponent, Duration: &duration, Jitter: &jitter},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.rateLimit.qps")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.rateLimit.bucketWrite")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.backoff.retries")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.backoff.exponent")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.backoff.duration")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("cloudProviderConfig.backoff.jitter")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig volumeSnapshots", func() {
+		It("should pass for a valid snapshot resource group", func() {
+			controlPlaneConfig.VolumeSnapshots = &apisazure.VolumeSnapshotConfig{ResourceGroup: util.StringPtr("my-snapshots_(1).rg")}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid snapshot resource groups", func() {
+			for _, resourceGroup := range []string{"", "my-snapshots.", "my/snapshots", strings.Repeat("a", 91)} {
+				controlPlaneConfig.VolumeSnapshots = &apisazure.VolumeSnapshotConfig{ResourceGroup: util.StringPtr(resourceGroup)}
+
+				Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("volumeSnapshots.resourceGroup")})),
+				))
+			}
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfig storageClasses", func() {
+		It("should pass for valid storage classes", func() {
+			replace := apisazure.StorageClassesModeReplace
+			controlPlaneConfig.StorageClasses = &apisazure.StorageClasses{
+				Mode: &replace,
+				Classes: []apisazure.StorageClass{
+					{
+						Name:              "premium-zrs",
+						SKU:               "Premium_ZRS",
+						Default:           util.BoolPtr(true),
+						VolumeBindingMode: util.StringPtr("WaitForFirstConsumer"),
+						ReclaimPolicy:     util.StringPtr("Retain"),
+						Parameters:        map[string]string{"cachingMode": "None"},
+					},
+					{
+						Name: "standard-ssd",
+						SKU:  "StandardSSD_LRS",
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(BeEmpty())
+		})
+
+		It("should forbid invalid storage classes", func() {
+			mode := apisazure.StorageClassesMode("Merge")
+			controlPlaneConfig.StorageClasses = &apisazure.StorageClasses{
+				Mode: &mode,
+				Classes: []apisazure.StorageClass{
+					{
+						SKU:     "Premium_LRS",
+						Default: util.BoolPtr(true),
+					},
+					{
+						Name:              "Fast_Disks",
+						SKU:               "Premium",
+						Default:           util.BoolPtr(true),
+						VolumeBindingMode: util.StringPtr("Later"),
+						ReclaimPolicy:     util.StringPtr("Recycle"),
+						Parameters:        map[string]string{"skuName": "Premium_LRS"},
+					},
+					{
+						Name: "Fast_Disks",
+						SKU:  "Standard_LRS",
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, version)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("storageClasses.mode")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeRequired), "Field": Equal("storageClasses.classes[0].name")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("storageClasses.classes[1].name")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("storageClasses.classes[1].sku")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("storageClasses.classes[1].default")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("storageClasses.classes[1].volumeBindingMode")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeNotSupported), "Field": Equal("storageClasses.classes[1].reclaimPolicy")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("storageClasses.classes[1].parameters[skuName]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeInvalid), "Field": Equal("storageClasses.classes[2].name")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeDuplicate), "Field": Equal("storageClasses.classes[2].name")})),
+			))
+		})
+
+		It("should forbid zone-redundant disks for Kubernetes versions < 1.21", func() {
+			controlPlaneConfig.StorageClasses = &apisazure.StorageClasses{
+				Classes: []apisazure.StorageClass{{Name: "standard-zrs", SKU: "StandardSSD_ZRS"}},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, "1.20.5")).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("storageClasses.classes[0].sku")})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfigUpdate", func() {
+		It("should allow the migration to standard load balancers", func() {
+			newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+			newControlPlaneConfig.LoadBalancerSKU = &standard
+
+			Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(BeEmpty())
+		})
+
+		It("should forbid the migration back to basic load balancers", func() {
+			controlPlaneConfig.LoadBalancerSKU = &standard
+			newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+			newControlPlaneConfig.LoadBalancerSKU = &basic
+
+			Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("loadBalancerSKU"),
+			}))
+		})
+
+		It("should forbid removing the standard load balancer sku", func() {
+			controlPlaneConfig.LoadBalancerSKU = &standard
+			newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+			newControlPlaneConfig.LoadBalancerSKU = nil
+
+			Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("loadBalancerSKU"),
+			}))
+		})
+
+		Context("kms", func() {
+			BeforeEach(func() {
+				controlPlaneConfig.KMS = &apisazure.KMSConfig{
+					KeyVaultName: "my-vault",
+					KeyName:      "etcd-encryption",
+					KeyVersion:   "0123456789abcdef0123456789abcdef",
+				}
+			})
+
+			It("should allow rotating the key", func() {
+				newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+				newControlPlaneConfig.KMS.KeyVersion = "fedcba9876543210fedcba9876543210"
+
+				Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(BeEmpty())
+			})
+
+			It("should forbid changing the key vault or the key", func() {
+				newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+				newControlPlaneConfig.KMS.KeyVaultName = "other-vault"
+				newControlPlaneConfig.KMS.KeyName = "other-key"
+
+				Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("kms.keyVaultName")})),
+					PointTo(MatchFields(IgnoreExtras, Fields{"Type": Equal(field.ErrorTypeForbidden), "Field": Equal("kms.keyName")})),
+				))
+			})
+
+			It("should forbid disabling the encryption", func() {
+				newControlPlaneConfig := controlPlaneConfig.DeepCopy()
+				newControlPlaneConfig.KMS = nil
+
+				Expect(ValidateControlPlaneConfigUpdate(controlPlaneConfig, newControlPlaneConfig)).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("kms"),
+				}))
+			})
+		})
+	})
+})
@@ -15,31 +15,67 @@
 package validation
 
 import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 
 	cidrvalidation "github.com/gardener/gardener/pkg/utils/validation/cidr"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+const (
+	// securityRulePriorityMin is the lowest priority Azure accepts for a security rule.
+	securityRulePriorityMin = 100
+	// securityRulePriorityMax is the highest priority Azure accepts for a security rule.
+	securityRulePriorityMax = 4096
+)
+
+var (
+	// vnetIDRegex is used to validate the resource IDs of remote VNets.
+	vnetIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/virtualNetworks/[^/]+$`)
+	// privateDNSZoneIDRegex is used to validate the resource IDs of private DNS zones.
+	privateDNSZoneIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/privateDnsZones/[^/]+$`)
+	// resourceNameRegex is used to validate the names of resources which are rendered into the Terraform configuration.
+	resourceNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+	validSecurityRuleDirections = sets.NewString(string(apisazure.SecurityRuleDirectionInbound), string(apisazure.SecurityRuleDirectionOutbound))
+	validSecurityRuleAccesses   = sets.NewString(string(apisazure.SecurityRuleAccessAllow), string(apisazure.SecurityRuleAccessDeny))
+	validSecurityRuleProtocols  = sets.NewString(string(apisazure.SecurityRuleProtocolTCP), string(apisazure.SecurityRuleProtocolUDP), string(apisazure.SecurityRuleProtocolICMP), string(apisazure.SecurityRuleProtocolAll))
+	validRouteNextHopTypes      = sets.NewString(
+		string(apisazure.RouteNextHopTypeVirtualNetworkGateway),
+		string(apisazure.RouteNextHopTypeVnetLocal),
+		string(apisazure.RouteNextHopTypeInternet),
+		string(apisazure.RouteNextHopTypeVirtualAppliance),
+		string(apisazure.RouteNextHopTypeNone),
+	)
+)
+
 // ValidateInfrastructureConfig validates a InfrastructureConfig object.
 func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, resourceGroupName, nodesCIDR, podsCIDR, servicesCIDR *string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	var (
-		nodes    cidrvalidation.CIDR
-		pods     cidrvalidation.CIDR
-		services cidrvalidation.CIDR
+		nodes        cidrvalidation.CIDR
+		pods         cidrvalidation.CIDR
+		podsIPv6     cidrvalidation.CIDR
+		services     cidrvalidation.CIDR
+		servicesIPv6 cidrvalidation.CIDR
 	)
 
 	if nodesCIDR != nil {
 		nodes = cidrvalidation.NewCIDR(*nodesCIDR, nil)
 	}
 	if podsCIDR != nil {
-		pods = cidrvalidation.NewCIDR(*podsCIDR, nil)
+		pods, podsIPv6 = splitDualStackCIDRs(*podsCIDR)
 	}
 	if servicesCIDR != nil {
-		services = cidrvalidation.NewCIDR(*servicesCIDR, nil)
+		services, servicesIPv6 = splitDualStackCIDRs(*servicesCIDR)
 	}
 
 	// Currently, we will not allow deployments into existing resource groups or VNets although this functionality
@@ -52,15 +88,25 @@ func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, resourc
 	}
 
 	networksPath := field.NewPath("networks")
-	if len(infra.Networks.Workers) == 0 {
-		allErrs = append(allErrs, field.Required(networksPath.Child("workers"), "must specify the network range for the worker network"))
-	}
+	zoned := len(infra.Networks.Zones) > 0
 
-	workerCIDR := cidrvalidation.NewCIDR(infra.Networks.Workers, networksPath.Child("workers"))
+	var workerCIDR cidrvalidation.CIDR
+	if zoned {
+		if len(infra.Networks.Workers) > 0 {
+			allErrs = append(allErrs, field.Forbidden(networksPath.Child("workers"), "must not specify the worker network together with zones"))
+		}
+	} else {
+		if len(infra.Networks.Workers) == 0 {
+			allErrs = append(allErrs, field.Required(networksPath.Child("workers"), "must specify the network range for the worker network"))
+		}
+
+		workerCIDR = cidrvalidation.NewCIDR(infra.Networks.Workers, networksPath.Child("workers"))
 
-	allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(workerCIDR)...)
-	allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(networksPath.Child("workers"), infra.Networks.Workers)...)
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(workerCIDR)...)
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(networksPath.Child("workers"), infra.Networks.Workers)...)
+	}
 
+	var vnetCIDR cidrvalidation.CIDR
 	if (infra.Networks.VNet.Name != nil && infra.Networks.VNet.ResourceGroup == nil) || (infra.Networks.VNet.Name == nil && infra.Networks.VNet.ResourceGroup != nil) {
 		allErrs = append(allErrs, field.Invalid(networksPath.Child("vnet"), infra.Networks.VNet, "specifying an existing vnet name require a vnet name and vnet resource group"))
 	} else if infra.Networks.VNet.Name != nil && infra.Networks.VNet.ResourceGroup != nil {
@@ -70,26 +116,590 @@ func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, resourc
 		if *infra.Networks.VNet.ResourceGroup == *resourceGroupName {
 			allErrs = append(allErrs, field.Invalid(networksPath.Child("vnet", "resourceGroup"), *infra.Networks.VNet.ResourceGroup, "specifying an existing vnet is the cluster resource group is not supported"))
 		}
+		if len(infra.Networks.VNet.DNSServers) > 0 {
+			allErrs = append(allErrs, field.Forbidden(networksPath.Child("vnet", "dnsServers"), "specifying dns servers for an existing vnet is not possible"))
+		}
 	} else {
 		cidrPath := networksPath.Child("vnet", "cidr")
 		if infra.Networks.VNet.CIDR == nil {
-			// Use worker/subnet cidr as cidr for the vnet.
-			allErrs = append(allErrs, workerCIDR.ValidateSubset(nodes)...)
-			allErrs = append(allErrs, workerCIDR.ValidateNotSubset(pods, services)...)
+			if zoned {
+				allErrs = append(allErrs, field.Required(cidrPath, "must specify the vnet cidr when using zones"))
+			} else {
+				// Use worker/subnet cidr as cidr for the vnet.
+				allErrs = append(allErrs, workerCIDR.ValidateSubset(nodes)...)
+				allErrs = append(allErrs, workerCIDR.ValidateNotSubset(pods, services)...)
+			}
 		} else {
-			vpcCIDR := cidrvalidation.NewCIDR(*(infra.Networks.VNet.CIDR), cidrPath)
-			allErrs = append(allErrs, vpcCIDR.ValidateParse()...)
-			allErrs = append(allErrs, vpcCIDR.ValidateSubset(nodes)...)
-			allErrs = append(allErrs, vpcCIDR.ValidateSubset(workerCIDR)...)
-			allErrs = append(allErrs, vpcCIDR.ValidateNotSubset(pods, services)...)
+			vnetCIDR = cidrvalidation.NewCIDR(*(infra.Networks.VNet.CIDR), cidrPath)
+			allErrs = append(allErrs, vnetCIDR.ValidateParse()...)
+			allErrs = append(allErrs, vnetCIDR.ValidateSubset(nodes)...)
+			if workerCIDR != nil {
+				allErrs = append(allErrs, vnetCIDR.ValidateSubset(workerCIDR)...)
+			}
+			allErrs = append(allErrs, vnetCIDR.ValidateNotSubset(pods, services)...)
 			allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, *infra.Networks.VNet.CIDR)...)
 		}
 	}
 
-	if nodes != nil {
+	if nodes != nil && workerCIDR != nil {
 		allErrs = append(allErrs, nodes.ValidateSubset(workerCIDR)...)
 	}
 
+	if zoned {
+		allErrs = append(allErrs, validateZones(infra, vnetCIDR, nodes, pods, services, networksPath.Child("zones"))...)
+	}
+	allErrs = append(allErrs, validateIPv6Networks(infra.Networks, podsIPv6, servicesIPv6, networksPath)...)
+	allErrs = append(allErrs, validateSubnets(infra.Networks, pods, services, networksPath)...)
+	allErrs = append(allErrs, validateRoutes(infra.Networks.Routes, pods, networksPath.Child("routes"))...)
+	allErrs = append(allErrs, validateDNSServers(infra.Networks.VNet.DNSServers, networksPath.Child("vnet", "dnsServers"))...)
+	allErrs = append(allErrs, validatePrivateDNSZoneIDs(infra.Networks.VNet.PrivateDNSZoneIDs, networksPath.Child("vnet", "privateDNSZoneIDs"))...)
+	// The subnets have to be checked as well since the nodes CIDR and the VNet CIDR are optional for existing VNets.
+	clusterCIDRs := []cidrvalidation.CIDR{vnetCIDR, workerCIDR, nodes, pods, services, podsIPv6, servicesIPv6}
+	for _, zone := range infra.Networks.Zones {
+		clusterCIDRs = append(clusterCIDRs, cidrvalidation.NewCIDR(zone.CIDR, nil))
+	}
+	for _, subnet := range infra.Networks.Subnets {
+		clusterCIDRs = append(clusterCIDRs, cidrvalidation.NewCIDR(subnet.CIDR, nil))
+	}
+	if infra.Networks.IPv6Workers != nil {
+		clusterCIDRs = append(clusterCIDRs, cidrvalidation.NewCIDR(*infra.Networks.IPv6Workers, nil))
+	}
+	if infra.Networks.VNet.IPv6CIDR != nil {
+		clusterCIDRs = append(clusterCIDRs, cidrvalidation.NewCIDR(*infra.Networks.VNet.IPv6CIDR, nil))
+	}
+	allErrs = append(allErrs, validateVNetPeerings(infra.Networks.VNet.Peerings, clusterCIDRs, networksPath.Child("vnet", "peerings"))...)
+	allErrs = append(allErrs, validateApplicationSecurityGroups(infra.Networks.ApplicationSecurityGroups, networksPath.Child("applicationSecurityGroups"))...)
+	allErrs = append(allErrs, validateSecurityRules(infra.Networks.SecurityRules, infra.Networks.ApplicationSecurityGroups, networksPath.Child("securityRules"))...)
+	allErrs = append(allErrs, validateProximityPlacementGroups(infra.ProximityPlacementGroups, infra.Zoned, field.NewPath("proximityPlacementGroups"))...)
+
+	if infra.Zoned && infra.AvailabilitySetPerWorkerPool {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("availabilitySetPerWorkerPool"), "availability sets can only be used for non zoned clusters"))
+	}
+
+	return allErrs
+}
+
+// validateProximityPlacementGroups validates the given proximity placement groups. The machines of a proximity
+// placement group must be located in the same zone, hence, zoned clusters need one group per zone. Non-zoned clusters
+// can only use one group because it is associated with the availability set of the cluster.
+func validateProximityPlacementGroups(proximityPlacementGroups []apisazure.ProximityPlacementGroup, zoned bool, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs = field.ErrorList{}
+		keys    = sets.NewString()
+	)
+
+	if !zoned && len(proximityPlacementGroups) > 1 {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(proximityPlacementGroups), 1))
+	}
+
+	for i, proximityPlacementGroup := range proximityPlacementGroups {
+		idxPath := fldPath.Index(i)
+
+		if !resourceNameRegex.MatchString(proximityPlacementGroup.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), proximityPlacementGroup.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+
+		key := proximityPlacementGroup.Name
+		if zoned {
+			if proximityPlacementGroup.Zone == nil || len(*proximityPlacementGroup.Zone) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("zone"), "must specify the zone of the proximity placement group for zoned clusters"))
+				continue
+			}
+			key = fmt.Sprintf("%s/%s", proximityPlacementGroup.Name, *proximityPlacementGroup.Zone)
+		} else if proximityPlacementGroup.Zone != nil {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("zone"), "must not specify a zone for proximity placement groups of non-zoned clusters"))
+		}
+
+		if keys.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, proximityPlacementGroup))
+		}
+		keys.Insert(key)
+	}
+
+	return allErrs
+}
+
+// splitDualStackCIDRs splits the given comma-separated CIDRs of a (dual-stack) network into its IPv4 and IPv6 CIDR.
+// Malformed CIDRs are treated as IPv4 so that their parse errors are reported by the IPv4 validation.
+func splitDualStackCIDRs(cidrs string) (cidrvalidation.CIDR, cidrvalidation.CIDR) {
+	var ipv4, ipv6 cidrvalidation.CIDR
+
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if isIPv6CIDR(cidr) {
+			if ipv6 == nil {
+				ipv6 = cidrvalidation.NewCIDR(cidr, nil)
+			}
+		} else if ipv4 == nil {
+			ipv4 = cidrvalidation.NewCIDR(cidr, nil)
+		}
+	}
+
+	return ipv4, ipv6
+}
+
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// validateIPv6Networks validates the IPv6 ranges of dual-stack clusters. Azure requires IPv6 subnets to have a prefix
+// length of exactly /64.
+func validateIPv6Networks(networks apisazure.NetworkConfig, podsIPv6, servicesIPv6 cidrvalidation.CIDR, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs         = field.ErrorList{}
+		ipv6WorkersPath = fldPath.Child("ipv6Workers")
+		ipv6CIDRPath    = fldPath.Child("vnet", "ipv6CIDR")
+	)
+
+	if networks.IPv6Workers == nil {
+		if podsIPv6 != nil || servicesIPv6 != nil {
+			allErrs = append(allErrs, field.Required(ipv6WorkersPath, "must specify the ipv6 worker network for dual-stack pod and service networks"))
+		}
+		if networks.VNet.IPv6CIDR != nil {
+			allErrs = append(allErrs, field.Required(ipv6WorkersPath, "must specify the ipv6 worker network if an ipv6 vnet cidr is given"))
+		}
+		return allErrs
+	}
+
+	if len(networks.Zones) > 0 {
+		allErrs = append(allErrs, field.Forbidden(ipv6WorkersPath, "dual-stack is not supported together with zones"))
+	}
+
+	workersIPv6 := cidrvalidation.NewCIDR(*networks.IPv6Workers, ipv6WorkersPath)
+	if errs := validateIPv6CIDR(workersIPv6); len(errs) > 0 {
+		return append(allErrs, errs...)
+	}
+	if ones, _ := workersIPv6.GetIPNet().Mask.Size(); ones != 64 {
+		allErrs = append(allErrs, field.Invalid(ipv6WorkersPath, *networks.IPv6Workers, "must have a prefix length of /64"))
+	}
+	allErrs = append(allErrs, validateNotOverlapping(workersIPv6, podsIPv6, servicesIPv6)...)
+
+	if networks.VNet.IPv6CIDR == nil {
+		return allErrs
+	}
+
+	if networks.VNet.Name != nil {
+		return append(allErrs, field.Forbidden(ipv6CIDRPath, "specifying an ipv6 cidr for an existing vnet is not possible"))
+	}
+
+	vnetIPv6 := cidrvalidation.NewCIDR(*networks.VNet.IPv6CIDR, ipv6CIDRPath)
+	if errs := validateIPv6CIDR(vnetIPv6); len(errs) > 0 {
+		return append(allErrs, errs...)
+	}
+	allErrs = append(allErrs, vnetIPv6.ValidateSubset(workersIPv6)...)
+	allErrs = append(allErrs, validateNotOverlapping(vnetIPv6, podsIPv6, servicesIPv6)...)
+
+	return allErrs
+}
+
+func validateIPv6CIDR(cidr cidrvalidation.CIDR) field.ErrorList {
+	if errs := cidr.ValidateParse(); len(errs) > 0 {
+		return errs
+	}
+	if cidr.GetIPNet().IP.To4() != nil {
+		return field.ErrorList{field.Invalid(cidr.GetFieldPath(), cidr.GetCIDR(), "must be an ipv6 cidr")}
+	}
+	return cidrvalidation.ValidateCIDRIsCanonical(cidr.GetFieldPath(), cidr.GetCIDR())
+}
+
+func validateNotOverlapping(cidr cidrvalidation.CIDR, others ...cidrvalidation.CIDR) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, other := range others {
+		if other == nil || !other.Parse() {
+			continue
+		}
+		if cidrvalidation.NetworksIntersect(cidr.GetCIDR(), other.GetCIDR()) {
+			allErrs = append(allErrs, field.Invalid(cidr.GetFieldPath(), cidr.GetCIDR(), fmt.Sprintf("must not overlap with %q", other.GetCIDR())))
+		}
+	}
+
+	return allErrs
+}
+
+func validateZones(infra *apisazure.InfrastructureConfig, vnetCIDR, nodes, pods, services cidrvalidation.CIDR, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs = field.ErrorList{}
+		names   = sets.NewString()
+		cidrs   []string
+	)
+
+	if !infra.Zoned {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "zones can only be specified for zoned clusters"))
+	}
+
+	for i, zone := range infra.Networks.Zones {
+		idxPath := fldPath.Index(i)
+
+		if name, err := strconv.Atoi(zone.Name); err != nil || name <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), zone.Name, "zone name must be a positive number"))
+		}
+		if names.Has(zone.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), zone.Name))
+		}
+		names.Insert(zone.Name)
+
+		cidrPath := idxPath.Child("cidr")
+		zoneCIDR := cidrvalidation.NewCIDR(zone.CIDR, cidrPath)
+		if errs := zoneCIDR.ValidateParse(); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, zone.CIDR)...)
+		if vnetCIDR != nil {
+			allErrs = append(allErrs, vnetCIDR.ValidateSubset(zoneCIDR)...)
+		}
+		if nodes != nil {
+			allErrs = append(allErrs, nodes.ValidateSubset(zoneCIDR)...)
+		}
+		allErrs = append(allErrs, zoneCIDR.ValidateNotSubset(pods, services)...)
+
+		for _, cidr := range cidrs {
+			if cidrvalidation.NetworksIntersect(zone.CIDR, cidr) {
+				allErrs = append(allErrs, field.Invalid(cidrPath, zone.CIDR, fmt.Sprintf("must not overlap with %q", cidr)))
+			}
+		}
+		cidrs = append(cidrs, zone.CIDR)
+	}
+
+	return allErrs
+}
+
+func validateSubnets(networks apisazure.NetworkConfig, pods, services cidrvalidation.CIDR, networksPath *field.Path) field.ErrorList {
+	var (
+		allErrs        = field.ErrorList{}
+		fldPath        = networksPath.Child("subnets")
+		names          = sets.NewString()
+		securityGroups = sets.NewString()
+		cidrs          []string
+		vnetCIDR       cidrvalidation.CIDR
+	)
+
+	if len(networks.Subnets) == 0 {
+		return allErrs
+	}
+
+	if len(networks.Workers) > 0 {
+		cidrs = append(cidrs, networks.Workers)
+	}
+	for _, zone := range networks.Zones {
+		cidrs = append(cidrs, zone.CIDR)
+	}
+
+	// Without an existing VNet or an explicit VNet CIDR the worker CIDR is used as address space of the VNet,
+	// hence, there is no room for additional subnets.
+	if networks.VNet.Name == nil && networks.VNet.CIDR == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "additional subnets require either an existing vnet or a vnet cidr"))
+	}
+	if networks.VNet.CIDR != nil {
+		vnetCIDR = cidrvalidation.NewCIDR(*networks.VNet.CIDR, networksPath.Child("vnet", "cidr"))
+	}
+
+	for i, subnet := range networks.Subnets {
+		idxPath := fldPath.Index(i)
+
+		if !resourceNameRegex.MatchString(subnet.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), subnet.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+		if names.Has(subnet.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), subnet.Name))
+		}
+		names.Insert(subnet.Name)
+
+		cidrPath := idxPath.Child("cidr")
+		subnetCIDR := cidrvalidation.NewCIDR(subnet.CIDR, cidrPath)
+		if errs := subnetCIDR.ValidateParse(); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else {
+			allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, subnet.CIDR)...)
+			if vnetCIDR != nil {
+				allErrs = append(allErrs, vnetCIDR.ValidateSubset(subnetCIDR)...)
+			}
+			allErrs = append(allErrs, subnetCIDR.ValidateNotSubset(pods, services)...)
+
+			for _, cidr := range cidrs {
+				if cidrvalidation.NetworksIntersect(subnet.CIDR, cidr) {
+					allErrs = append(allErrs, field.Invalid(cidrPath, subnet.CIDR, fmt.Sprintf("must not overlap with %q", cidr)))
+				}
+			}
+			cidrs = append(cidrs, subnet.CIDR)
+		}
+
+		if subnet.SecurityGroup != nil {
+			securityGroupPath := idxPath.Child("securityGroup")
+			if !resourceNameRegex.MatchString(*subnet.SecurityGroup) {
+				allErrs = append(allErrs, field.Invalid(securityGroupPath, *subnet.SecurityGroup, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+			}
+			if securityGroups.Has(*subnet.SecurityGroup) {
+				allErrs = append(allErrs, field.Duplicate(securityGroupPath, *subnet.SecurityGroup))
+			}
+			securityGroups.Insert(*subnet.SecurityGroup)
+		}
+	}
+
+	return allErrs
+}
+
+// validateRoutes validates the given user-defined routes. Routes into the pod network are forbidden because the
+// cloud-controller-manager maintains the routes for the pod ranges of the nodes in the same route table.
+func validateRoutes(routes []apisazure.Route, pods cidrvalidation.CIDR, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs         = field.ErrorList{}
+		names           = sets.NewString()
+		addressPrefixes = sets.NewString()
+	)
+
+	for i, route := range routes {
+		idxPath := fldPath.Index(i)
+
+		if !resourceNameRegex.MatchString(route.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), route.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+		if names.Has(route.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), route.Name))
+		}
+		names.Insert(route.Name)
+
+		addressPrefixPath := idxPath.Child("addressPrefix")
+		addressPrefix := cidrvalidation.NewCIDR(route.AddressPrefix, addressPrefixPath)
+		if errs := addressPrefix.ValidateParse(); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else {
+			allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(addressPrefixPath, route.AddressPrefix)...)
+			if pods != nil {
+				allErrs = append(allErrs, pods.ValidateNotSubset(addressPrefix)...)
+			}
+			if addressPrefixes.Has(route.AddressPrefix) {
+				allErrs = append(allErrs, field.Duplicate(addressPrefixPath, route.AddressPrefix))
+			}
+			addressPrefixes.Insert(route.AddressPrefix)
+		}
+
+		if !validRouteNextHopTypes.Has(string(route.NextHopType)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("nextHopType"), route.NextHopType, validRouteNextHopTypes.List()))
+		}
+
+		nextHopIPAddressPath := idxPath.Child("nextHopIPAddress")
+		if route.NextHopType == apisazure.RouteNextHopTypeVirtualAppliance {
+			if route.NextHopIPAddress == nil {
+				allErrs = append(allErrs, field.Required(nextHopIPAddressPath, "must specify the next hop ip address for virtual appliances"))
+			} else if net.ParseIP(*route.NextHopIPAddress) == nil {
+				allErrs = append(allErrs, field.Invalid(nextHopIPAddressPath, *route.NextHopIPAddress, "must be a valid ip address"))
+			}
+		} else if route.NextHopIPAddress != nil {
+			allErrs = append(allErrs, field.Forbidden(nextHopIPAddressPath, fmt.Sprintf("next hop ip address can only be specified for next hop type %s", apisazure.RouteNextHopTypeVirtualAppliance)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateDNSServers(dnsServers []string, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs   = field.ErrorList{}
+		addresses = sets.NewString()
+	)
+
+	for i, dnsServer := range dnsServers {
+		idxPath := fldPath.Index(i)
+
+		if net.ParseIP(dnsServer) == nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, dnsServer, "must be a valid ip address"))
+		}
+		if addresses.Has(dnsServer) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, dnsServer))
+		}
+		addresses.Insert(dnsServer)
+	}
+
+	return allErrs
+}
+
+// validatePrivateDNSZoneIDs validates the given private DNS zone IDs. Azure does not allow to link several private DNS
+// zones with the same name to one VNet, hence, the zone names must be unique. The zones can only be linked if they
+// belong to the subscription of the shoot which is checked by ValidateInfrastructureConfigAgainstSubscription; here
+// all zones are required to belong to the same subscription.
+func validatePrivateDNSZoneIDs(privateDNSZoneIDs []string, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs        = field.ErrorList{}
+		zoneNames      = sets.NewString()
+		subscriptionID string
+	)
+
+	for i, privateDNSZoneID := range privateDNSZoneIDs {
+		idxPath := fldPath.Index(i)
+
+		if !privateDNSZoneIDRegex.MatchString(privateDNSZoneID) {
+			allErrs = append(allErrs, field.Invalid(idxPath, privateDNSZoneID, "must be the resource id of a private dns zone"))
+			continue
+		}
+
+		zoneName := strings.ToLower(privateDNSZoneID[strings.LastIndex(privateDNSZoneID, "/")+1:])
+		if zoneNames.Has(zoneName) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, privateDNSZoneID))
+		}
+		zoneNames.Insert(zoneName)
+
+		zoneSubscriptionID := strings.Split(privateDNSZoneID, "/")[2]
+		if len(subscriptionID) == 0 {
+			subscriptionID = zoneSubscriptionID
+		} else if !strings.EqualFold(subscriptionID, zoneSubscriptionID) {
+			allErrs = append(allErrs, field.Invalid(idxPath, privateDNSZoneID, fmt.Sprintf("must belong to the subscription of the shoot, i.e. the same subscription %q as the other private dns zones", subscriptionID)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateVNetPeerings validates the given VNet peerings. The known remote address spaces must not overlap with any
+// of the given cluster networks.
+func validateVNetPeerings(peerings []apisazure.VNetPeering, clusterCIDRs []cidrvalidation.CIDR, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs = field.ErrorList{}
+		names   = sets.NewString()
+	)
+
+	for i, peering := range peerings {
+		idxPath := fldPath.Index(i)
+
+		if !resourceNameRegex.MatchString(peering.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), peering.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+		if names.Has(peering.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), peering.Name))
+		}
+		names.Insert(peering.Name)
+
+		if len(peering.RemoteVNetID) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("remoteVNetID"), "must specify the resource id of the remote vnet"))
+		} else if !vnetIDRegex.MatchString(peering.RemoteVNetID) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("remoteVNetID"), peering.RemoteVNetID, "must be the resource id of a virtual network"))
+		}
+
+		for j, addressSpace := range peering.RemoteAddressSpaces {
+			addressSpacePath := idxPath.Child("remoteAddressSpaces").Index(j)
+			remoteCIDR := cidrvalidation.NewCIDR(addressSpace, addressSpacePath)
+			if errs := remoteCIDR.ValidateParse(); len(errs) > 0 {
+				allErrs = append(allErrs, errs...)
+				continue
+			}
+
+			for _, clusterCIDR := range clusterCIDRs {
+				if clusterCIDR == nil || !clusterCIDR.Parse() {
+					continue
+				}
+				if cidrvalidation.NetworksIntersect(addressSpace, clusterCIDR.GetCIDR()) {
+					allErrs = append(allErrs, field.Invalid(addressSpacePath, addressSpace, fmt.Sprintf("must not overlap with %q", clusterCIDR.GetCIDR())))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateApplicationSecurityGroups(applicationSecurityGroups []apisazure.ApplicationSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs = field.ErrorList{}
+		names   = sets.NewString()
+	)
+
+	for i, applicationSecurityGroup := range applicationSecurityGroups {
+		namePath := fldPath.Index(i).Child("name")
+
+		if !resourceNameRegex.MatchString(applicationSecurityGroup.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, applicationSecurityGroup.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+		if names.Has(applicationSecurityGroup.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, applicationSecurityGroup.Name))
+		}
+		names.Insert(applicationSecurityGroup.Name)
+	}
+
+	return allErrs
+}
+
+func validateSecurityRules(securityRules []apisazure.SecurityRule, applicationSecurityGroups []apisazure.ApplicationSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs    = field.ErrorList{}
+		names      = sets.NewString()
+		priorities = map[apisazure.SecurityRuleDirection]sets.Int32{}
+		asgNames   = sets.NewString()
+	)
+
+	for _, applicationSecurityGroup := range applicationSecurityGroups {
+		asgNames.Insert(applicationSecurityGroup.Name)
+	}
+
+	for i, rule := range securityRules {
+		idxPath := fldPath.Index(i)
+
+		if !resourceNameRegex.MatchString(rule.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), rule.Name, fmt.Sprintf("name must match the regex %s", resourceNameRegex)))
+		}
+		if names.Has(rule.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), rule.Name))
+		}
+		names.Insert(rule.Name)
+
+		if rule.Priority < securityRulePriorityMin || rule.Priority > securityRulePriorityMax {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("priority"), rule.Priority, fmt.Sprintf("priority must be between %d and %d", securityRulePriorityMin, securityRulePriorityMax)))
+		}
+		if !validSecurityRuleDirections.Has(string(rule.Direction)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("direction"), rule.Direction, validSecurityRuleDirections.List()))
+		} else {
+			if _, ok := priorities[rule.Direction]; !ok {
+				priorities[rule.Direction] = sets.NewInt32()
+			}
+			if priorities[rule.Direction].Has(rule.Priority) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("priority"), rule.Priority))
+			}
+			priorities[rule.Direction].Insert(rule.Priority)
+		}
+		if !validSecurityRuleAccesses.Has(string(rule.Access)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("access"), rule.Access, validSecurityRuleAccesses.List()))
+		}
+		if !validSecurityRuleProtocols.Has(string(rule.Protocol)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("protocol"), rule.Protocol, validSecurityRuleProtocols.List()))
+		}
+
+		if rule.SourceAddressPrefix != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("sourceAddressPrefix"), *rule.SourceAddressPrefix, "specifying a source address prefix together with source application security groups is not possible"))
+		}
+		if rule.DestinationAddressPrefix != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("destinationAddressPrefix"), *rule.DestinationAddressPrefix, "specifying a destination address prefix together with destination application security groups is not possible"))
+		}
+		for j, name := range rule.SourceApplicationSecurityGroups {
+			if !asgNames.Has(name) {
+				allErrs = append(allErrs, field.NotFound(idxPath.Child("sourceApplicationSecurityGroups").Index(j), name))
+			}
+		}
+		for j, name := range rule.DestinationApplicationSecurityGroups {
+			if !asgNames.Has(name) {
+				allErrs = append(allErrs, field.NotFound(idxPath.Child("destinationApplicationSecurityGroups").Index(j), name))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateInfrastructureConfigAgainstSubscription validates a InfrastructureConfig object against the subscription of
+// the shoot. The subscription is only known from the cloud provider secret of the shoot.
+func ValidateInfrastructureConfigAgainstSubscription(infra *apisazure.InfrastructureConfig, subscriptionID string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("networks", "vnet", "privateDNSZoneIDs")
+	for i, privateDNSZoneID := range infra.Networks.VNet.PrivateDNSZoneIDs {
+		if !privateDNSZoneIDRegex.MatchString(privateDNSZoneID) {
+			continue
+		}
+		if zoneSubscriptionID := strings.Split(privateDNSZoneID, "/")[2]; !strings.EqualFold(zoneSubscriptionID, subscriptionID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), privateDNSZoneID, fmt.Sprintf("must belong to the subscription %q of the shoot", subscriptionID)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -98,7 +708,61 @@ func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apisazure.Infrastr
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.ResourceGroup, oldConfig.ResourceGroup, field.NewPath("resourceGroup"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.Networks, oldConfig.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(immutableNetworkConfig(newConfig.Networks), immutableNetworkConfig(oldConfig.Networks), field.NewPath("networks"))...)
+	allErrs = append(allErrs, validateSubnetsUpdate(oldConfig.Networks.Subnets, newConfig.Networks.Subnets, field.NewPath("networks", "subnets"))...)
+
+	// Non-zoned clusters can be migrated to zones but not the other way round. The proximity placement group of a
+	// non-zoned cluster cannot be kept as its availability set is only kept until its machines have been migrated.
+	zonedPath := field.NewPath("zoned")
+	if oldConfig.Zoned && !newConfig.Zoned {
+		allErrs = append(allErrs, field.Forbidden(zonedPath, "zoned clusters cannot be migrated to availability sets"))
+	}
+	if !oldConfig.Zoned && newConfig.Zoned && len(oldConfig.ProximityPlacementGroups) > 0 {
+		allErrs = append(allErrs, field.Forbidden(zonedPath, "clusters with proximity placement groups cannot be migrated to zones"))
+	}
+
+	// The proximity placement group of non-zoned clusters is associated with the availability set which cannot be changed.
+	// Existing machines cannot be moved into other availability sets either.
+	if !oldConfig.Zoned && !newConfig.Zoned {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.ProximityPlacementGroups, oldConfig.ProximityPlacementGroups, field.NewPath("proximityPlacementGroups"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.AvailabilitySetPerWorkerPool, oldConfig.AvailabilitySetPerWorkerPool, field.NewPath("availabilitySetPerWorkerPool"))...)
+	}
 
 	return allErrs
 }
+
+// validateSubnetsUpdate validates that subnets which already exist keep their CIDR and security group. Subnets
+// may be added or removed.
+func validateSubnetsUpdate(oldSubnets, newSubnets []apisazure.WorkerSubnet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldSubnetsByName := make(map[string]apisazure.WorkerSubnet, len(oldSubnets))
+	for _, subnet := range oldSubnets {
+		oldSubnetsByName[subnet.Name] = subnet
+	}
+
+	for i, subnet := range newSubnets {
+		oldSubnet, ok := oldSubnetsByName[subnet.Name]
+		if !ok {
+			continue
+		}
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(subnet.CIDR, oldSubnet.CIDR, fldPath.Index(i).Child("cidr"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(subnet.SecurityGroup, oldSubnet.SecurityGroup, fldPath.Index(i).Child("securityGroup"))...)
+	}
+
+	return allErrs
+}
+
+// immutableNetworkConfig returns a copy of the given network configuration without the fields which are allowed to
+// be changed after the infrastructure has been created.
+func immutableNetworkConfig(networks apisazure.NetworkConfig) *apisazure.NetworkConfig {
+	out := networks.DeepCopy()
+	out.ApplicationSecurityGroups = nil
+	out.SecurityRules = nil
+	out.Subnets = nil
+	out.VNet.Peerings = nil
+	out.VNet.DNSServers = nil
+	out.VNet.PrivateDNSZoneIDs = nil
+	out.Routes = nil
+	return out
+}
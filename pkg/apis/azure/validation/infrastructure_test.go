@@ -122,6 +122,22 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				}))
 			})
 
+			It("should forbid specifying dns servers for an existing vnet", func() {
+				name := "existing-vnet"
+				vnetGroup := "existing-vnet-rg"
+				infrastructureConfig.Networks.VNet = apisazure.VNet{
+					Name:          &name,
+					ResourceGroup: &vnetGroup,
+					DNSServers:    []string{"10.1.0.4"},
+				}
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.vnet.dnsServers"),
+				}))
+			})
+
 			It("should pass if no vnet cidr is specified and default is applied", func() {
 				nodes = "10.250.3.0/24"
 				infrastructureConfig.Networks = apisazure.NetworkConfig{
@@ -222,6 +238,686 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				}))
 			})
 		})
+		Context("application security groups and security rules", func() {
+			var onPrem = "192.168.0.0/16"
+
+			BeforeEach(func() {
+				infrastructureConfig.Networks.ApplicationSecurityGroups = []apisazure.ApplicationSecurityGroup{
+					{Name: "frontend"},
+				}
+				infrastructureConfig.Networks.SecurityRules = []apisazure.SecurityRule{
+					{
+						Name:                                 "allow-on-prem",
+						Priority:                             200,
+						Direction:                            apisazure.SecurityRuleDirectionInbound,
+						Access:                               apisazure.SecurityRuleAccessAllow,
+						Protocol:                             apisazure.SecurityRuleProtocolTCP,
+						SourceAddressPrefix:                  &onPrem,
+						DestinationApplicationSecurityGroups: []string{"frontend"},
+					},
+				}
+			})
+
+			It("should pass for valid application security groups and security rules", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid invalid and duplicate application security group names", func() {
+				infrastructureConfig.Networks.ApplicationSecurityGroups = append(infrastructureConfig.Networks.ApplicationSecurityGroups,
+					apisazure.ApplicationSecurityGroup{Name: "frontend"},
+					apisazure.ApplicationSecurityGroup{Name: "1-Invalid"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.applicationSecurityGroups[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.applicationSecurityGroups[2].name"),
+				}))
+			})
+
+			It("should forbid invalid security rules", func() {
+				infrastructureConfig.Networks.SecurityRules[0].Priority = 5000
+				infrastructureConfig.Networks.SecurityRules[0].Direction = "Sideways"
+				infrastructureConfig.Networks.SecurityRules[0].Access = "Maybe"
+				infrastructureConfig.Networks.SecurityRules[0].Protocol = "Sctp"
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityRules[0].priority"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityRules[0].direction"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityRules[0].access"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityRules[0].protocol"),
+				}))
+			})
+
+			It("should forbid duplicate security rule names and priorities", func() {
+				infrastructureConfig.Networks.SecurityRules = append(infrastructureConfig.Networks.SecurityRules, infrastructureConfig.Networks.SecurityRules[0])
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.securityRules[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.securityRules[1].priority"),
+				}))
+			})
+
+			It("should forbid security rules referencing unknown application security groups", func() {
+				infrastructureConfig.Networks.SecurityRules[0].SourceAddressPrefix = nil
+				infrastructureConfig.Networks.SecurityRules[0].SourceApplicationSecurityGroups = []string{"backend"}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("networks.securityRules[0].sourceApplicationSecurityGroups[0]"),
+				}))
+			})
+
+			It("should forbid specifying an address prefix together with application security groups", func() {
+				destination := "10.250.0.0/16"
+				infrastructureConfig.Networks.SecurityRules[0].DestinationAddressPrefix = &destination
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityRules[0].destinationAddressPrefix"),
+				}))
+			})
+		})
+
+		Context("zones", func() {
+			BeforeEach(func() {
+				infrastructureConfig.Zoned = true
+				infrastructureConfig.Networks.Workers = ""
+				infrastructureConfig.Networks.Zones = []apisazure.Zone{
+					{Name: "1", CIDR: "10.250.0.0/19"},
+					{Name: "2", CIDR: "10.250.32.0/19"},
+				}
+			})
+
+			It("should pass for valid zones", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid zones for non zoned clusters and together with workers", func() {
+				infrastructureConfig.Zoned = false
+				infrastructureConfig.Networks.Workers = "10.250.64.0/19"
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.workers"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.zones"),
+				}))
+			})
+
+			It("should require a vnet cidr", func() {
+				infrastructureConfig.Networks.VNet.CIDR = nil
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.vnet.cidr"),
+				}))
+			})
+
+			It("should forbid invalid and duplicate zone names", func() {
+				infrastructureConfig.Networks.Zones[1].Name = "1"
+				infrastructureConfig.Networks.Zones = append(infrastructureConfig.Networks.Zones,
+					apisazure.Zone{Name: "0", CIDR: "10.250.64.0/19"},
+					apisazure.Zone{Name: "a", CIDR: "10.250.96.0/19"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.zones[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.zones[2].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.zones[3].name"),
+				}))
+			})
+
+			It("should forbid zone CIDRs which are invalid, overlapping or not in the VNet and nodes CIDR", func() {
+				infrastructureConfig.Networks.Zones = append(infrastructureConfig.Networks.Zones,
+					apisazure.Zone{Name: "3", CIDR: invalidCIDR},
+					apisazure.Zone{Name: "4", CIDR: "10.250.16.0/20"},
+					apisazure.Zone{Name: "5", CIDR: "10.251.0.0/19"},
+					apisazure.Zone{Name: "6", CIDR: "11.0.0.0/19"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.zones[2].cidr"),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.zones[3].cidr"),
+					"Detail": Equal(`must not overlap with "10.250.0.0/19"`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.zones[4].cidr"),
+					"Detail": ContainSubstring(`("10.250.0.0/16")`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.zones[5].cidr"),
+					"Detail": Equal(`must be a subset of "networks.vnet.cidr" ("10.0.0.0/8")`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.zones[5].cidr"),
+					"Detail": ContainSubstring(`("10.250.0.0/16")`),
+				}))
+			})
+		})
+
+		Context("proximity placement groups", func() {
+			var zone1, zone2 = "1", "2"
+
+			It("should pass for one proximity placement group of a non-zoned cluster", func() {
+				infrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{{Name: "trading"}}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should pass for one proximity placement group per zone of a zoned cluster", func() {
+				infrastructureConfig.Zoned = true
+				infrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{
+					{Name: "trading", Zone: &zone1},
+					{Name: "trading", Zone: &zone2},
+					{Name: "analytics", Zone: &zone1},
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid invalid proximity placement groups of a non-zoned cluster", func() {
+				infrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{
+					{Name: "trading", Zone: &zone1},
+					{Name: "Analytics"},
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeTooMany),
+					"Field": Equal("proximityPlacementGroups"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("proximityPlacementGroups[0].zone"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("proximityPlacementGroups[1].name"),
+				}))
+			})
+
+			It("should forbid invalid proximity placement groups of a zoned cluster", func() {
+				infrastructureConfig.Zoned = true
+				infrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{
+					{Name: "trading", Zone: &zone1},
+					{Name: "trading", Zone: &zone1},
+					{Name: "analytics"},
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("proximityPlacementGroups[1]"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("proximityPlacementGroups[2].zone"),
+				}))
+			})
+		})
+
+		It("should forbid one availability set per worker pool for a zoned cluster", func() {
+			infrastructureConfig.Zoned = true
+			infrastructureConfig.AvailabilitySetPerWorkerPool = true
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("availabilitySetPerWorkerPool"),
+			}))
+		})
+
+		Context("dual-stack", func() {
+			var (
+				dualStackPods     = "100.96.0.0/11,fd00:10:96::/48"
+				dualStackServices = "100.64.0.0/13,fd00:10:64::/108"
+				vnetIPv6CIDR      string
+				ipv6Workers       string
+			)
+
+			BeforeEach(func() {
+				vnetIPv6CIDR = "fd00:10:250::/48"
+				ipv6Workers = "fd00:10:250::/64"
+				infrastructureConfig.Networks.VNet.IPv6CIDR = &vnetIPv6CIDR
+				infrastructureConfig.Networks.IPv6Workers = &ipv6Workers
+			})
+
+			It("should pass for valid dual-stack networks", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should pass if the vnet ipv6 cidr is omitted", func() {
+				infrastructureConfig.Networks.VNet.IPv6CIDR = nil
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should require the ipv6 worker network for dual-stack pod and service networks", func() {
+				infrastructureConfig.Networks.IPv6Workers = nil
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.ipv6Workers"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.ipv6Workers"),
+				}))
+			})
+
+			It("should forbid invalid ipv6 networks", func() {
+				ipv6Workers = "10.251.0.0/24"
+				vnetIPv6CIDR = "fd00:10:250::1/48"
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.ipv6Workers"),
+					"Detail": Equal("must be an ipv6 cidr"),
+				}))
+			})
+
+			It("should forbid ipv6 worker networks with a wrong prefix length or outside of the vnet", func() {
+				ipv6Workers = "fd00:10:251::/56"
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.ipv6Workers"),
+					"Detail": Equal("must have a prefix length of /64"),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.ipv6Workers"),
+					"Detail": ContainSubstring("must be a subset of"),
+				}))
+			})
+
+			It("should forbid ipv6 networks overlapping with the pod and service networks", func() {
+				ipv6Workers = "fd00:10:96::/64"
+				vnetIPv6CIDR = "fd00:10::/32"
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.ipv6Workers"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.ipv6CIDR"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.ipv6CIDR"),
+				}))
+			})
+
+			It("should forbid an ipv6 cidr for an existing vnet and dual-stack together with zones", func() {
+				name := "existing-vnet"
+				vnetGroup := "existing-vnet-rg"
+				infrastructureConfig.Networks.VNet = apisazure.VNet{Name: &name, ResourceGroup: &vnetGroup, IPv6CIDR: &vnetIPv6CIDR}
+				infrastructureConfig.Networks.Workers = ""
+				infrastructureConfig.Networks.Zones = []apisazure.Zone{{Name: "1", CIDR: "10.250.3.0/24"}}
+				infrastructureConfig.Zoned = true
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &dualStackPods, &dualStackServices)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.ipv6Workers"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.vnet.ipv6CIDR"),
+				}))
+			})
+		})
+
+		Context("dns", func() {
+			It("should pass for valid dns servers and private dns zones", func() {
+				infrastructureConfig.Networks.VNet.DNSServers = []string{"10.1.0.4", "10.1.0.5"}
+				infrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid invalid dns servers and private dns zones", func() {
+				infrastructureConfig.Networks.VNet.DNSServers = []string{"10.1.0.4", "10.1.0", "10.1.0.4"}
+				infrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/virtualNetworks/hub",
+					"/subscriptions/sub/resourceGroups/other-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.dnsServers[1]"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.vnet.dnsServers[2]"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.privateDNSZoneIDs[1]"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.vnet.privateDNSZoneIDs[2]"),
+				}))
+			})
+
+			It("should forbid private dns zones of different subscriptions", func() {
+				infrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+					"/subscriptions/other-sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+				}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.privateDNSZoneIDs[1]"),
+				}))
+			})
+		})
+
+		Context("private dns zones against subscription", func() {
+			It("should allow private dns zones of the subscription of the shoot", func() {
+				infrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{
+					"/subscriptions/SUB/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+				}
+
+				Expect(ValidateInfrastructureConfigAgainstSubscription(infrastructureConfig, "sub")).To(BeEmpty())
+			})
+
+			It("should forbid private dns zones of another subscription", func() {
+				infrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{
+					"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com",
+					"/subscriptions/other-sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+				}
+
+				errorList := ValidateInfrastructureConfigAgainstSubscription(infrastructureConfig, "sub")
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.privateDNSZoneIDs[1]"),
+				}))
+			})
+		})
+
+		Context("vnet peerings", func() {
+			BeforeEach(func() {
+				infrastructureConfig.Networks.VNet.Peerings = []apisazure.VNetPeering{
+					{
+						Name:                  "hub",
+						RemoteVNetID:          "/subscriptions/sub/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub",
+						AllowForwardedTraffic: true,
+						RemoteAddressSpaces:   []string{"172.16.0.0/16"},
+					},
+				}
+			})
+
+			It("should pass for valid peerings", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid invalid peerings", func() {
+				infrastructureConfig.Networks.VNet.Peerings = append(infrastructureConfig.Networks.VNet.Peerings,
+					apisazure.VNetPeering{Name: "hub", RemoteVNetID: "/subscriptions/sub/resourceGroups/hub-rg"},
+					apisazure.VNetPeering{Name: "Hub_2"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.vnet.peerings[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.peerings[1].remoteVNetID"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.peerings[2].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.vnet.peerings[2].remoteVNetID"),
+				}))
+			})
+
+			It("should forbid remote address spaces overlapping with the cluster networks", func() {
+				infrastructureConfig.Networks.VNet.Peerings[0].RemoteAddressSpaces = []string{invalidCIDR, "10.250.128.0/17", pods, "100.64.0.0/16"}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.vnet.peerings[0].remoteAddressSpaces[0]"),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[1]"),
+					"Detail": Equal(`must not overlap with "10.0.0.0/8"`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[1]"),
+					"Detail": Equal(`must not overlap with "10.250.0.0/16"`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[2]"),
+					"Detail": Equal(`must not overlap with "100.96.0.0/11"`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[3]"),
+					"Detail": Equal(`must not overlap with "100.64.0.0/13"`),
+				}))
+			})
+
+			It("should forbid remote address spaces overlapping with the subnets of an existing vnet", func() {
+				vnetName, vnetResourceGroup := "existing-vnet", "existing-vnet-rg"
+				infrastructureConfig.Networks.VNet.Name = &vnetName
+				infrastructureConfig.Networks.VNet.ResourceGroup = &vnetResourceGroup
+				infrastructureConfig.Networks.VNet.CIDR = nil
+				infrastructureConfig.Networks.Subnets = []apisazure.WorkerSubnet{{Name: "dmz", CIDR: "10.251.0.0/24"}}
+				infrastructureConfig.Networks.VNet.Peerings[0].RemoteAddressSpaces = []string{"10.250.3.128/25", "10.251.0.0/16"}
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, nil, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[0]"),
+					"Detail": Equal(`must not overlap with "10.250.3.0/24"`),
+				}, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.vnet.peerings[0].remoteAddressSpaces[1]"),
+					"Detail": Equal(`must not overlap with "10.251.0.0/24"`),
+				}))
+			})
+		})
+
+		Context("routes", func() {
+			var firewallIP = "10.250.100.4"
+
+			BeforeEach(func() {
+				infrastructureConfig.Networks.Routes = []apisazure.Route{
+					{
+						Name:             "default-via-firewall",
+						AddressPrefix:    "0.0.0.0/0",
+						NextHopType:      apisazure.RouteNextHopTypeVirtualAppliance,
+						NextHopIPAddress: &firewallIP,
+					},
+				}
+			})
+
+			It("should pass for valid routes", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid invalid routes", func() {
+				invalidIP := "10.250.100"
+				infrastructureConfig.Networks.Routes = append(infrastructureConfig.Networks.Routes,
+					apisazure.Route{Name: "default-via-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: apisazure.RouteNextHopTypeInternet, NextHopIPAddress: &firewallIP},
+					apisazure.Route{Name: "pods", AddressPrefix: "100.96.1.0/24", NextHopType: "Elsewhere"},
+					apisazure.Route{Name: "invalid", AddressPrefix: invalidCIDR, NextHopType: apisazure.RouteNextHopTypeVirtualAppliance},
+					apisazure.Route{Name: "invalid-ip", AddressPrefix: "192.168.0.0/16", NextHopType: apisazure.RouteNextHopTypeVirtualAppliance, NextHopIPAddress: &invalidIP},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.routes[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.routes[1].addressPrefix"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.routes[1].nextHopIPAddress"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.routes[2].addressPrefix"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.routes[2].nextHopType"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.routes[3].addressPrefix"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.routes[3].nextHopIPAddress"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.routes[4].nextHopIPAddress"),
+				}))
+			})
+		})
+
+		Context("subnets", func() {
+			var securityGroup = "dmz-nsg"
+
+			BeforeEach(func() {
+				infrastructureConfig.Networks.Subnets = []apisazure.WorkerSubnet{
+					{
+						Name:             "dmz",
+						CIDR:             "10.250.4.0/24",
+						ServiceEndpoints: []string{"Microsoft.Storage"},
+						SecurityGroup:    &securityGroup,
+					},
+				}
+			})
+
+			It("should pass for valid subnets", func() {
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid subnets if the vnet cidr is defaulted to the workers cidr", func() {
+				infrastructureConfig.Networks.VNet.CIDR = nil
+				nodes = infrastructureConfig.Networks.Workers
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.subnets"),
+				}))
+			})
+
+			It("should forbid invalid and duplicate subnet names and security groups", func() {
+				infrastructureConfig.Networks.Subnets = append(infrastructureConfig.Networks.Subnets,
+					apisazure.WorkerSubnet{Name: "dmz", CIDR: "10.250.5.0/24", SecurityGroup: &securityGroup},
+					apisazure.WorkerSubnet{Name: "Invalid_Name", CIDR: "10.250.6.0/24"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.subnets[1].name"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("networks.subnets[1].securityGroup"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnets[2].name"),
+				}))
+			})
+
+			It("should forbid invalid subnet CIDRs", func() {
+				infrastructureConfig.Networks.Subnets = append(infrastructureConfig.Networks.Subnets,
+					apisazure.WorkerSubnet{Name: "invalid", CIDR: invalidCIDR},
+					apisazure.WorkerSubnet{Name: "outside", CIDR: "11.0.0.0/24"},
+					apisazure.WorkerSubnet{Name: "workers", CIDR: "10.250.3.128/25"},
+					apisazure.WorkerSubnet{Name: "overlap", CIDR: "10.250.4.0/23"},
+				)
+
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &resourceGroup, &nodes, &pods, &services)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnets[1].cidr"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnets[2].cidr"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnets[3].cidr"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnets[4].cidr"),
+				}))
+			})
+		})
 	})
 
 	Describe("#ValidateInfrastructureConfigUpdate", func() {
@@ -253,5 +949,129 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				"Field": Equal("networks"),
 			}))))
 		})
+
+		It("should allow changing the application security groups and security rules", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.ApplicationSecurityGroups = []apisazure.ApplicationSecurityGroup{{Name: "frontend"}}
+			newInfrastructureConfig.Networks.SecurityRules = []apisazure.SecurityRule{{Name: "allow-frontend"}}
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should allow changing the routes", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Routes = []apisazure.Route{{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: apisazure.RouteNextHopTypeInternet}}
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should forbid changing the proximity placement group of a non-zoned cluster", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{{Name: "trading"}}
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("proximityPlacementGroups"),
+			}))
+		})
+
+		It("should allow migrating a non-zoned cluster to zones", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Zoned = true
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should forbid migrating a zoned cluster to availability sets", func() {
+			infrastructureConfig.Zoned = true
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Zoned = false
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("zoned"),
+			}))
+		})
+
+		It("should forbid migrating a non-zoned cluster with a proximity placement group to zones", func() {
+			infrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{{Name: "trading"}}
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Zoned = true
+			newInfrastructureConfig.ProximityPlacementGroups = nil
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("zoned"),
+			}))
+		})
+
+		It("should forbid changing the availability set mode of a non-zoned cluster", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.AvailabilitySetPerWorkerPool = true
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("availabilitySetPerWorkerPool"),
+			}))
+		})
+
+		It("should forbid changing the ipv6 networks", func() {
+			ipv6Workers := "fd00:10:250::/64"
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.IPv6Workers = &ipv6Workers
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks"),
+			}))
+		})
+
+		It("should allow changing the proximity placement groups of a zoned cluster", func() {
+			zone := "1"
+			infrastructureConfig.Zoned = true
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.ProximityPlacementGroups = []apisazure.ProximityPlacementGroup{{Name: "trading", Zone: &zone}}
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should allow changing the dns servers and private dns zones", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.VNet.DNSServers = []string{"10.1.0.4"}
+			newInfrastructureConfig.Networks.VNet.PrivateDNSZoneIDs = []string{"/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.example.com"}
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should allow changing the vnet peerings", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.VNet.Peerings = []apisazure.VNetPeering{{Name: "hub"}}
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)).To(BeEmpty())
+		})
+
+		It("should allow adding subnets but forbid changing existing ones", func() {
+			infrastructureConfig.Networks.Subnets = []apisazure.WorkerSubnet{{Name: "dmz", CIDR: "10.250.4.0/24"}}
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Subnets[0].CIDR = "10.250.5.0/24"
+			newInfrastructureConfig.Networks.Subnets = append(newInfrastructureConfig.Networks.Subnets, apisazure.WorkerSubnet{Name: "backend", CIDR: "10.250.6.0/24"})
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, &nodes, &pods, &services)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks.subnets[0].cidr"),
+			}))
+		})
 	})
 })
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// dedicatedHostGroupIDRegex is used to validate the resource IDs of dedicated host groups.
+var dedicatedHostGroupIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/hostGroups/[^/]+$`)
+
+// ValidateWorkerConfig validates a WorkerConfig object against the InfrastructureConfig of the shoot and the zones
+// of the worker pool.
+func ValidateWorkerConfig(workerConfig *apisazure.WorkerConfig, infra *apisazure.InfrastructureConfig, zones []string) field.ErrorList {
+	var (
+		allErrs  = field.ErrorList{}
+		asgNames = sets.NewString()
+		seen     = sets.NewString()
+	)
+
+	for _, applicationSecurityGroup := range infra.Networks.ApplicationSecurityGroups {
+		asgNames.Insert(applicationSecurityGroup.Name)
+	}
+
+	asgPath := field.NewPath("applicationSecurityGroups")
+	for i, name := range workerConfig.ApplicationSecurityGroups {
+		if !asgNames.Has(name) {
+			allErrs = append(allErrs, field.NotFound(asgPath.Index(i), name))
+		}
+		if seen.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(asgPath.Index(i), name))
+		}
+		seen.Insert(name)
+	}
+
+	if workerConfig.Subnet != nil {
+		found := false
+		for _, subnet := range infra.Networks.Subnets {
+			if subnet.Name == *workerConfig.Subnet {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(field.NewPath("subnet"), *workerConfig.Subnet))
+		}
+	}
+
+	if workerConfig.ProximityPlacementGroup != nil {
+		found := false
+		for _, proximityPlacementGroup := range infra.ProximityPlacementGroups {
+			if proximityPlacementGroup.Name == *workerConfig.ProximityPlacementGroup {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(field.NewPath("proximityPlacementGroup"), *workerConfig.ProximityPlacementGroup))
+		}
+	}
+
+	if workerConfig.DedicatedHostGroup != nil {
+		allErrs = append(allErrs, validateDedicatedHostGroup(workerConfig.DedicatedHostGroup, infra.Zoned, zones, field.NewPath("dedicatedHostGroup"))...)
+	}
+
+	return allErrs
+}
+
+func validateDedicatedHostGroup(dedicatedHostGroup *apisazure.DedicatedHostGroup, zoned bool, zones []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	idPath := fldPath.Child("id")
+	if len(dedicatedHostGroup.ID) == 0 {
+		allErrs = append(allErrs, field.Required(idPath, "must provide the resource id of the dedicated host group"))
+	} else if !dedicatedHostGroupIDRegex.MatchString(dedicatedHostGroup.ID) {
+		allErrs = append(allErrs, field.Invalid(idPath, dedicatedHostGroup.ID, "must be the resource id of a dedicated host group"))
+	}
+
+	if dedicatedHostGroup.HostID != nil {
+		hostPrefix := strings.ToLower(dedicatedHostGroup.ID + "/hosts/")
+		hostID := strings.ToLower(*dedicatedHostGroup.HostID)
+		if !strings.HasPrefix(hostID, hostPrefix) || len(hostID) == len(hostPrefix) || strings.Contains(strings.TrimPrefix(hostID, hostPrefix), "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostID"), *dedicatedHostGroup.HostID, "must be the resource id of a host of the dedicated host group"))
+		}
+	}
+
+	// Availability sets are not supported on dedicated hosts, hence, the machines of non zoned clusters cannot be
+	// placed on them.
+	if !zoned {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "dedicated host groups are only supported for zoned clusters"))
+		return allErrs
+	}
+
+	zonePath := fldPath.Child("zone")
+	if dedicatedHostGroup.Zone == nil {
+		allErrs = append(allErrs, field.Required(zonePath, "must provide the zone of the dedicated host group"))
+		return allErrs
+	}
+	for _, zone := range zones {
+		if zone != *dedicatedHostGroup.Zone {
+			allErrs = append(allErrs, field.Invalid(zonePath, *dedicatedHostGroup.Zone, "must match the zones of the worker pool"))
+			break
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	. "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
+
+	. "github.com/gardener/gardener/pkg/utils/validation/gomega"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("WorkerConfig validation", func() {
+	var (
+		workerConfig *apisazure.WorkerConfig
+		infraConfig  *apisazure.InfrastructureConfig
+		zones        []string
+	)
+
+	BeforeEach(func() {
+		subnet := "dmz"
+		proximityPlacementGroup := "trading"
+		workerConfig = &apisazure.WorkerConfig{
+			ApplicationSecurityGroups: []string{"frontend"},
+			Subnet:                    &subnet,
+			ProximityPlacementGroup:   &proximityPlacementGroup,
+		}
+		infraConfig = &apisazure.InfrastructureConfig{
+			Networks: apisazure.NetworkConfig{
+				ApplicationSecurityGroups: []apisazure.ApplicationSecurityGroup{
+					{Name: "frontend"},
+				},
+				Subnets: []apisazure.WorkerSubnet{
+					{Name: "dmz", CIDR: "10.250.32.0/19"},
+				},
+			},
+			ProximityPlacementGroups: []apisazure.ProximityPlacementGroup{
+				{Name: "trading"},
+			},
+		}
+		zones = nil
+	})
+
+	Describe("#ValidateWorkerConfig", func() {
+		It("should pass for a valid configuration", func() {
+			Expect(ValidateWorkerConfig(workerConfig, infraConfig, zones)).To(BeEmpty())
+		})
+
+		It("should forbid referencing unknown application security groups", func() {
+			workerConfig.ApplicationSecurityGroups = append(workerConfig.ApplicationSecurityGroups, "backend")
+
+			errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotFound),
+				"Field": Equal("applicationSecurityGroups[1]"),
+			}))
+		})
+
+		It("should forbid referencing an application security group twice", func() {
+			workerConfig.ApplicationSecurityGroups = append(workerConfig.ApplicationSecurityGroups, "frontend")
+
+			errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("applicationSecurityGroups[1]"),
+			}))
+		})
+
+		It("should forbid referencing an unknown subnet", func() {
+			subnet := "backend"
+			workerConfig.Subnet = &subnet
+
+			errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotFound),
+				"Field": Equal("subnet"),
+			}))
+		})
+
+		It("should forbid referencing an unknown proximity placement group", func() {
+			proximityPlacementGroup := "analytics"
+			workerConfig.ProximityPlacementGroup = &proximityPlacementGroup
+
+			errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotFound),
+				"Field": Equal("proximityPlacementGroup"),
+			}))
+		})
+
+		Context("dedicated host group", func() {
+			const hostGroupID = "/subscriptions/sub/resourceGroups/hosts/providers/Microsoft.Compute/hostGroups/licensed"
+
+			BeforeEach(func() {
+				zone := "1"
+				workerConfig.DedicatedHostGroup = &apisazure.DedicatedHostGroup{ID: hostGroupID, Zone: &zone}
+				infraConfig.Zoned = true
+				zones = []string{"1"}
+			})
+
+			It("should pass for a valid dedicated host group", func() {
+				hostID := hostGroupID + "/hosts/host-1"
+				workerConfig.DedicatedHostGroup.HostID = &hostID
+
+				Expect(ValidateWorkerConfig(workerConfig, infraConfig, zones)).To(BeEmpty())
+			})
+
+			It("should forbid an invalid dedicated host group id", func() {
+				workerConfig.DedicatedHostGroup.ID = "/subscriptions/sub/resourceGroups/hosts/providers/Microsoft.Network/virtualNetworks/licensed"
+
+				errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("dedicatedHostGroup.id"),
+				}))
+			})
+
+			It("should forbid a host which does not belong to the dedicated host group", func() {
+				hostID := "/subscriptions/sub/resourceGroups/hosts/providers/Microsoft.Compute/hostGroups/other/hosts/host-1"
+				workerConfig.DedicatedHostGroup.HostID = &hostID
+
+				errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("dedicatedHostGroup.hostID"),
+				}))
+			})
+
+			It("should require the zone of the dedicated host group", func() {
+				workerConfig.DedicatedHostGroup.Zone = nil
+
+				errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("dedicatedHostGroup.zone"),
+				}))
+			})
+
+			It("should forbid a dedicated host group whose zone does not match the zones of the worker pool", func() {
+				zones = []string{"1", "2"}
+
+				errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("dedicatedHostGroup.zone"),
+				}))
+			})
+
+			It("should forbid dedicated host groups for non zoned clusters", func() {
+				infraConfig.Zoned = false
+				workerConfig.DedicatedHostGroup.Zone = nil
+				zones = nil
+
+				errorList := ValidateWorkerConfig(workerConfig, infraConfig, zones)
+
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("dedicatedHostGroup"),
+				}))
+			})
+		})
+	})
+})
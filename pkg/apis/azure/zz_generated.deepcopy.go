@@ -24,9 +24,67 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACRCredentialProviderConfig) DeepCopyInto(out *ACRCredentialProviderConfig) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACRCredentialProviderConfig.
+func (in *ACRCredentialProviderConfig) DeepCopy() *ACRCredentialProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACRCredentialProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSecurityGroup) DeepCopyInto(out *ApplicationSecurityGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroup.
+func (in *ApplicationSecurityGroup) DeepCopy() *ApplicationSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSecurityGroupStatus) DeepCopyInto(out *ApplicationSecurityGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroupStatus.
+func (in *ApplicationSecurityGroupStatus) DeepCopy() *ApplicationSecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AvailabilitySet) DeepCopyInto(out *AvailabilitySet) {
 	*out = *in
+	if in.WorkerPool != nil {
+		in, out := &in.WorkerPool, &out.WorkerPool
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -40,6 +98,47 @@ func (in *AvailabilitySet) DeepCopy() *AvailabilitySet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureADConfig) DeepCopyInto(out *AzureADConfig) {
+	*out = *in
+	if in.UsernameClaim != nil {
+		in, out := &in.UsernameClaim, &out.UsernameClaim
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsernamePrefix != nil {
+		in, out := &in.UsernamePrefix, &out.UsernamePrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.GroupsClaim != nil {
+		in, out := &in.GroupsClaim, &out.GroupsClaim
+		*out = new(string)
+		**out = **in
+	}
+	if in.GroupsPrefix != nil {
+		in, out := &in.GroupsPrefix, &out.GroupsPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValidateTenant != nil {
+		in, out := &in.ValidateTenant, &out.ValidateTenant
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureADConfig.
+func (in *AzureADConfig) DeepCopy() *AzureADConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureADConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudControllerManagerConfig) DeepCopyInto(out *CloudControllerManagerConfig) {
 	*out = *in
@@ -105,6 +204,114 @@ func (in *CloudProfileConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderBackoff) DeepCopyInto(out *CloudProviderBackoff) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Exponent != nil {
+		in, out := &in.Exponent, &out.Exponent
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderBackoff.
+func (in *CloudProviderBackoff) DeepCopy() *CloudProviderBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderConfig) DeepCopyInto(out *CloudProviderConfig) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(CloudProviderRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(CloudProviderBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderConfig.
+func (in *CloudProviderConfig) DeepCopy() *CloudProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderRateLimit) DeepCopyInto(out *CloudProviderRateLimit) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QPSWrite != nil {
+		in, out := &in.QPSWrite, &out.QPSWrite
+		*out = new(float64)
+		**out = **in
+	}
+	if in.BucketWrite != nil {
+		in, out := &in.BucketWrite, &out.BucketWrite
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderRateLimit.
+func (in *CloudProviderRateLimit) DeepCopy() *CloudProviderRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 	*out = *in
@@ -114,6 +321,51 @@ func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 		*out = new(CloudControllerManagerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancerSKU != nil {
+		in, out := &in.LoadBalancerSKU, &out.LoadBalancerSKU
+		*out = new(LoadBalancerSKU)
+		**out = **in
+	}
+	if in.CloudProviderConfig != nil {
+		in, out := &in.CloudProviderConfig, &out.CloudProviderConfig
+		*out = new(CloudProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = new(VolumeSnapshotConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = new(StorageClasses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeAPIServerExposure != nil {
+		in, out := &in.KubeAPIServerExposure, &out.KubeAPIServerExposure
+		*out = new(KubeAPIServerExposure)
+		**out = **in
+	}
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkServiceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSConfig)
+		**out = **in
+	}
+	if in.AzureAD != nil {
+		in, out := &in.AzureAD, &out.AzureAD
+		*out = new(AzureADConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACRCredentialProvider != nil {
+		in, out := &in.ACRCredentialProvider, &out.ACRCredentialProvider
+		*out = new(ACRCredentialProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -135,6 +387,62 @@ func (in *ControlPlaneConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneStatus) DeepCopyInto(out *ControlPlaneStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.PrivateLinkServiceAlias != nil {
+		in, out := &in.PrivateLinkServiceAlias, &out.PrivateLinkServiceAlias
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneStatus.
+func (in *ControlPlaneStatus) DeepCopy() *ControlPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControlPlaneStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedHostGroup) DeepCopyInto(out *DedicatedHostGroup) {
+	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedHostGroup.
+func (in *DedicatedHostGroup) DeepCopy() *DedicatedHostGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedHostGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainCount) DeepCopyInto(out *DomainCount) {
 	*out = *in
@@ -161,6 +469,13 @@ func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 		**out = **in
 	}
 	in.Networks.DeepCopyInto(&out.Networks)
+	if in.ProximityPlacementGroups != nil {
+		in, out := &in.ProximityPlacementGroups, &out.ProximityPlacementGroups
+		*out = make([]ProximityPlacementGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -191,7 +506,9 @@ func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 	if in.AvailabilitySets != nil {
 		in, out := &in.AvailabilitySets, &out.AvailabilitySets
 		*out = make([]AvailabilitySet, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.RouteTables != nil {
 		in, out := &in.RouteTables, &out.RouteTables
@@ -203,6 +520,18 @@ func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 		*out = make([]SecurityGroup, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]ApplicationSecurityGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProximityPlacementGroups != nil {
+		in, out := &in.ProximityPlacementGroups, &out.ProximityPlacementGroups
+		*out = make([]ProximityPlacementGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -224,6 +553,22 @@ func (in *InfrastructureStatus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSConfig) DeepCopyInto(out *KMSConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSConfig.
+func (in *KMSConfig) DeepCopy() *KMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineImage) DeepCopyInto(out *MachineImage) {
 	*out = *in
@@ -286,11 +631,47 @@ func (in *MachineImages) DeepCopy() *MachineImages {
 func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
 	*out = *in
 	in.VNet.DeepCopyInto(&out.VNet)
+	if in.IPv6Workers != nil {
+		in, out := &in.IPv6Workers, &out.IPv6Workers
+		*out = new(string)
+		**out = **in
+	}
 	if in.ServiceEndpoints != nil {
 		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]ApplicationSecurityGroup, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityRules != nil {
+		in, out := &in.SecurityRules, &out.SecurityRules
+		*out = make([]SecurityRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]WorkerSubnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]Zone, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -311,7 +692,9 @@ func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make([]Subnet, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -326,6 +709,90 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSZoneLinkStatus) DeepCopyInto(out *PrivateDNSZoneLinkStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSZoneLinkStatus.
+func (in *PrivateDNSZoneLinkStatus) DeepCopy() *PrivateDNSZoneLinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSZoneLinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceConfig) DeepCopyInto(out *PrivateLinkServiceConfig) {
+	*out = *in
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApproval != nil {
+		in, out := &in.AutoApproval, &out.AutoApproval
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceConfig.
+func (in *PrivateLinkServiceConfig) DeepCopy() *PrivateLinkServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProximityPlacementGroup) DeepCopyInto(out *ProximityPlacementGroup) {
+	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProximityPlacementGroup.
+func (in *ProximityPlacementGroup) DeepCopy() *ProximityPlacementGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProximityPlacementGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProximityPlacementGroupStatus) DeepCopyInto(out *ProximityPlacementGroupStatus) {
+	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProximityPlacementGroupStatus.
+func (in *ProximityPlacementGroupStatus) DeepCopy() *ProximityPlacementGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProximityPlacementGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceGroup) DeepCopyInto(out *ResourceGroup) {
 	*out = *in
@@ -342,6 +809,27 @@ func (in *ResourceGroup) DeepCopy() *ResourceGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	if in.NextHopIPAddress != nil {
+		in, out := &in.NextHopIPAddress, &out.NextHopIPAddress
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -374,9 +862,126 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
+	*out = *in
+	if in.SourceAddressPrefix != nil {
+		in, out := &in.SourceAddressPrefix, &out.SourceAddressPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourcePortRange != nil {
+		in, out := &in.SourcePortRange, &out.SourcePortRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationAddressPrefix != nil {
+		in, out := &in.DestinationAddressPrefix, &out.DestinationAddressPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.DestinationPortRange != nil {
+		in, out := &in.DestinationPortRange, &out.DestinationPortRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
+func (in *SecurityRule) DeepCopy() *SecurityRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClass) DeepCopyInto(out *StorageClass) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClass.
+func (in *StorageClass) DeepCopy() *StorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClasses) DeepCopyInto(out *StorageClasses) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(StorageClassesMode)
+		**out = **in
+	}
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]StorageClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClasses.
+func (in *StorageClasses) DeepCopy() *StorageClasses {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClasses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subnet) DeepCopyInto(out *Subnet) {
 	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -408,6 +1013,28 @@ func (in *VNet) DeepCopyInto(out *VNet) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IPv6CIDR != nil {
+		in, out := &in.IPv6CIDR, &out.IPv6CIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.Peerings != nil {
+		in, out := &in.Peerings, &out.Peerings
+		*out = make([]VNetPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateDNSZoneIDs != nil {
+		in, out := &in.PrivateDNSZoneIDs, &out.PrivateDNSZoneIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -421,6 +1048,43 @@ func (in *VNet) DeepCopy() *VNet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VNetPeering) DeepCopyInto(out *VNetPeering) {
+	*out = *in
+	if in.RemoteAddressSpaces != nil {
+		in, out := &in.RemoteAddressSpaces, &out.RemoteAddressSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VNetPeering.
+func (in *VNetPeering) DeepCopy() *VNetPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(VNetPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VNetPeeringStatus) DeepCopyInto(out *VNetPeeringStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VNetPeeringStatus.
+func (in *VNetPeeringStatus) DeepCopy() *VNetPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VNetPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VNetStatus) DeepCopyInto(out *VNetStatus) {
 	*out = *in
@@ -429,6 +1093,16 @@ func (in *VNetStatus) DeepCopyInto(out *VNetStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Peerings != nil {
+		in, out := &in.Peerings, &out.Peerings
+		*out = make([]VNetPeeringStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateDNSZoneLinks != nil {
+		in, out := &in.PrivateDNSZoneLinks, &out.PrivateDNSZoneLinks
+		*out = make([]PrivateDNSZoneLinkStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -442,6 +1116,72 @@ func (in *VNetStatus) DeepCopy() *VNetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotConfig) DeepCopyInto(out *VolumeSnapshotConfig) {
+	*out = *in
+	if in.ResourceGroup != nil {
+		in, out := &in.ResourceGroup, &out.ResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotConfig.
+func (in *VolumeSnapshotConfig) DeepCopy() *VolumeSnapshotConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProximityPlacementGroup != nil {
+		in, out := &in.ProximityPlacementGroup, &out.ProximityPlacementGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.DedicatedHostGroup != nil {
+		in, out := &in.DedicatedHostGroup, &out.DedicatedHostGroup
+		*out = new(DedicatedHostGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerConfig.
+func (in *WorkerConfig) DeepCopy() *WorkerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 	*out = *in
@@ -473,3 +1213,45 @@ func (in *WorkerStatus) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerSubnet) DeepCopyInto(out *WorkerSubnet) {
+	*out = *in
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerSubnet.
+func (in *WorkerSubnet) DeepCopy() *WorkerSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Zone) DeepCopyInto(out *Zone) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
+func (in *Zone) DeepCopy() *Zone {
+	if in == nil {
+		return nil
+	}
+	out := new(Zone)
+	in.DeepCopyInto(out)
+	return out
+}
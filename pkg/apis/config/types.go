@@ -17,6 +17,7 @@ package config
 import (
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -35,6 +36,13 @@ type ControllerConfiguration struct {
 	ETCD ETCD
 	// HealthCheckConfig is the config for the health check controller
 	HealthCheckConfig *healthcheckconfig.HealthCheckConfig
+	// KubeAPIServerExposure contains the settings for the exposure of the kube-apiservers of the shoots.
+	KubeAPIServerExposure *KubeAPIServerExposure
+	// KubeAPIServerService contains the settings for the load balancers of the kube-apiserver services of the shoots.
+	KubeAPIServerService *KubeAPIServerService
+	// ACRCredentialProvider contains the binary of the image credential provider for Azure Container Registries
+	// which is installed on the nodes of shoots that configure it.
+	ACRCredentialProvider *ACRCredentialProvider
 }
 
 // ETCD is an etcd configuration.
@@ -58,3 +66,43 @@ type ETCDBackup struct {
 	// Schedule is the etcd backup schedule.
 	Schedule *string
 }
+
+// KubeAPIServerExposure contains the settings for the exposure of the kube-apiservers of the shoots.
+type KubeAPIServerExposure struct {
+	// Private specifies whether the kube-apiservers are exposed on internal load balancers in the virtual network of
+	// the seed by default. Shoots can override it in their ControlPlaneConfig.
+	Private bool
+	// InternalLoadBalancerSubnet is the name of the subnet of the seed's virtual network in which the internal load
+	// balancers are created. Defaults to the subnet of the seed's nodes.
+	InternalLoadBalancerSubnet *string
+	// SeedSecretRef references a secret in the seed which contains the Azure credentials of the seed's subscription.
+	// They are used to look up the aliases of the Private Link Services of the kube-apiservers.
+	SeedSecretRef *corev1.SecretReference
+	// SeedResourceGroup is the resource group of the seed's load balancers in which the cloud provider of the seed
+	// creates the Private Link Services of the kube-apiservers.
+	SeedResourceGroup *string
+}
+
+// KubeAPIServerService contains the settings for the load balancers of the kube-apiserver services of the shoots. The
+// values of the annotations and of the name fields may contain the placeholder `$(SHOOT_NAMESPACE)` which is replaced by
+// the namespace of the shoot in the seed.
+type KubeAPIServerService struct {
+	// IdleTimeoutInMinutes is the TCP idle timeout of the load balancer. Defaults to 30 minutes.
+	IdleTimeoutInMinutes *int32
+	// PublicIPName is the name of an existing static public IP address which is used by the load balancer.
+	PublicIPName *string
+	// DNSLabelName is the DNS label of the public IP address of the load balancer.
+	DNSLabelName *string
+	// ResourceGroup is the resource group of the static public IP address of the load balancer.
+	ResourceGroup *string
+	// Annotations are additional annotations of the kube-apiserver services. The typed fields take precedence over them.
+	Annotations map[string]string
+}
+
+// ACRCredentialProvider contains the binary of the image credential provider for Azure Container Registries.
+type ACRCredentialProvider struct {
+	// URL is the URL from which the nodes download the binary of the credential provider.
+	URL string
+	// SHA256 is the SHA-256 digest of the binary. The nodes do not install binaries with another digest.
+	SHA256 string
+}
@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -38,6 +39,16 @@ type ControllerConfiguration struct {
 	// HealthCheckConfig is the config for the health check controller
 	// +optional
 	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig `json:"healthCheckConfig,omitempty"`
+	// KubeAPIServerExposure contains the settings for the exposure of the kube-apiservers of the shoots.
+	// +optional
+	KubeAPIServerExposure *KubeAPIServerExposure `json:"kubeAPIServerExposure,omitempty"`
+	// KubeAPIServerService contains the settings for the load balancers of the kube-apiserver services of the shoots.
+	// +optional
+	KubeAPIServerService *KubeAPIServerService `json:"kubeAPIServerService,omitempty"`
+	// ACRCredentialProvider contains the binary of the image credential provider for Azure Container Registries
+	// which is installed on the nodes of shoots that configure it.
+	// +optional
+	ACRCredentialProvider *ACRCredentialProvider `json:"acrCredentialProvider,omitempty"`
 }
 
 // ETCD is an etcd configuration.
@@ -64,3 +75,52 @@ type ETCDBackup struct {
 	// +optional
 	Schedule *string `json:"schedule,omitempty"`
 }
+
+// KubeAPIServerExposure contains the settings for the exposure of the kube-apiservers of the shoots.
+type KubeAPIServerExposure struct {
+	// Private specifies whether the kube-apiservers are exposed on internal load balancers in the virtual network of
+	// the seed by default. Shoots can override it in their ControlPlaneConfig.
+	// +optional
+	Private bool `json:"private,omitempty"`
+	// InternalLoadBalancerSubnet is the name of the subnet of the seed's virtual network in which the internal load
+	// balancers are created. Defaults to the subnet of the seed's nodes.
+	// +optional
+	InternalLoadBalancerSubnet *string `json:"internalLoadBalancerSubnet,omitempty"`
+	// SeedSecretRef references a secret in the seed which contains the Azure credentials of the seed's subscription.
+	// They are used to look up the aliases of the Private Link Services of the kube-apiservers.
+	// +optional
+	SeedSecretRef *corev1.SecretReference `json:"seedSecretRef,omitempty"`
+	// SeedResourceGroup is the resource group of the seed's load balancers in which the cloud provider of the seed
+	// creates the Private Link Services of the kube-apiservers.
+	// +optional
+	SeedResourceGroup *string `json:"seedResourceGroup,omitempty"`
+}
+
+// KubeAPIServerService contains the settings for the load balancers of the kube-apiserver services of the shoots. The
+// values of the annotations and of the name fields may contain the placeholder `$(SHOOT_NAMESPACE)` which is replaced by
+// the namespace of the shoot in the seed.
+type KubeAPIServerService struct {
+	// IdleTimeoutInMinutes is the TCP idle timeout of the load balancer. Defaults to 30 minutes.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// PublicIPName is the name of an existing static public IP address which is used by the load balancer.
+	// +optional
+	PublicIPName *string `json:"publicIPName,omitempty"`
+	// DNSLabelName is the DNS label of the public IP address of the load balancer.
+	// +optional
+	DNSLabelName *string `json:"dnsLabelName,omitempty"`
+	// ResourceGroup is the resource group of the static public IP address of the load balancer.
+	// +optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+	// Annotations are additional annotations of the kube-apiserver services. The typed fields take precedence over them.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ACRCredentialProvider contains the binary of the image credential provider for Azure Container Registries.
+type ACRCredentialProvider struct {
+	// URL is the URL from which the nodes download the binary of the credential provider.
+	URL string `json:"url"`
+	// SHA256 is the SHA-256 digest of the binary. The nodes do not install binaries with another digest.
+	SHA256 string `json:"sha256"`
+}
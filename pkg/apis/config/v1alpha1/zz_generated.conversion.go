@@ -26,6 +26,7 @@ import (
 	config "github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +41,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ACRCredentialProvider)(nil), (*config.ACRCredentialProvider)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ACRCredentialProvider_To_config_ACRCredentialProvider(a.(*ACRCredentialProvider), b.(*config.ACRCredentialProvider), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ACRCredentialProvider)(nil), (*ACRCredentialProvider)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ACRCredentialProvider_To_v1alpha1_ACRCredentialProvider(a.(*config.ACRCredentialProvider), b.(*ACRCredentialProvider), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -80,15 +91,60 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerExposure)(nil), (*config.KubeAPIServerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeAPIServerExposure_To_config_KubeAPIServerExposure(a.(*KubeAPIServerExposure), b.(*config.KubeAPIServerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.KubeAPIServerExposure)(nil), (*KubeAPIServerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(a.(*config.KubeAPIServerExposure), b.(*KubeAPIServerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerService)(nil), (*config.KubeAPIServerService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeAPIServerService_To_config_KubeAPIServerService(a.(*KubeAPIServerService), b.(*config.KubeAPIServerService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.KubeAPIServerService)(nil), (*KubeAPIServerService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KubeAPIServerService_To_v1alpha1_KubeAPIServerService(a.(*config.KubeAPIServerService), b.(*KubeAPIServerService), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_ACRCredentialProvider_To_config_ACRCredentialProvider(in *ACRCredentialProvider, out *config.ACRCredentialProvider, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	return nil
+}
+
+// Convert_v1alpha1_ACRCredentialProvider_To_config_ACRCredentialProvider is an autogenerated conversion function.
+func Convert_v1alpha1_ACRCredentialProvider_To_config_ACRCredentialProvider(in *ACRCredentialProvider, out *config.ACRCredentialProvider, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ACRCredentialProvider_To_config_ACRCredentialProvider(in, out, s)
+}
+
+func autoConvert_config_ACRCredentialProvider_To_v1alpha1_ACRCredentialProvider(in *config.ACRCredentialProvider, out *ACRCredentialProvider, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
 	return nil
 }
 
+// Convert_config_ACRCredentialProvider_To_v1alpha1_ACRCredentialProvider is an autogenerated conversion function.
+func Convert_config_ACRCredentialProvider_To_v1alpha1_ACRCredentialProvider(in *config.ACRCredentialProvider, out *ACRCredentialProvider, s conversion.Scope) error {
+	return autoConvert_config_ACRCredentialProvider_To_v1alpha1_ACRCredentialProvider(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*componentbaseconfig.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
 	if err := Convert_v1alpha1_ETCD_To_config_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfig.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	out.KubeAPIServerExposure = (*config.KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.KubeAPIServerService = (*config.KubeAPIServerService)(unsafe.Pointer(in.KubeAPIServerService))
+	out.ACRCredentialProvider = (*config.ACRCredentialProvider)(unsafe.Pointer(in.ACRCredentialProvider))
 	return nil
 }
 
@@ -103,6 +159,9 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfigv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	out.KubeAPIServerExposure = (*KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.KubeAPIServerService = (*KubeAPIServerService)(unsafe.Pointer(in.KubeAPIServerService))
+	out.ACRCredentialProvider = (*ACRCredentialProvider)(unsafe.Pointer(in.ACRCredentialProvider))
 	return nil
 }
 
@@ -182,3 +241,57 @@ func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStora
 func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
+
+func autoConvert_v1alpha1_KubeAPIServerExposure_To_config_KubeAPIServerExposure(in *KubeAPIServerExposure, out *config.KubeAPIServerExposure, s conversion.Scope) error {
+	out.Private = in.Private
+	out.InternalLoadBalancerSubnet = (*string)(unsafe.Pointer(in.InternalLoadBalancerSubnet))
+	out.SeedSecretRef = (*v1.SecretReference)(unsafe.Pointer(in.SeedSecretRef))
+	out.SeedResourceGroup = (*string)(unsafe.Pointer(in.SeedResourceGroup))
+	return nil
+}
+
+// Convert_v1alpha1_KubeAPIServerExposure_To_config_KubeAPIServerExposure is an autogenerated conversion function.
+func Convert_v1alpha1_KubeAPIServerExposure_To_config_KubeAPIServerExposure(in *KubeAPIServerExposure, out *config.KubeAPIServerExposure, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeAPIServerExposure_To_config_KubeAPIServerExposure(in, out, s)
+}
+
+func autoConvert_config_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in *config.KubeAPIServerExposure, out *KubeAPIServerExposure, s conversion.Scope) error {
+	out.Private = in.Private
+	out.InternalLoadBalancerSubnet = (*string)(unsafe.Pointer(in.InternalLoadBalancerSubnet))
+	out.SeedSecretRef = (*v1.SecretReference)(unsafe.Pointer(in.SeedSecretRef))
+	out.SeedResourceGroup = (*string)(unsafe.Pointer(in.SeedResourceGroup))
+	return nil
+}
+
+// Convert_config_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure is an autogenerated conversion function.
+func Convert_config_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in *config.KubeAPIServerExposure, out *KubeAPIServerExposure, s conversion.Scope) error {
+	return autoConvert_config_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeAPIServerService_To_config_KubeAPIServerService(in *KubeAPIServerService, out *config.KubeAPIServerService, s conversion.Scope) error {
+	out.IdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.IdleTimeoutInMinutes))
+	out.PublicIPName = (*string)(unsafe.Pointer(in.PublicIPName))
+	out.DNSLabelName = (*string)(unsafe.Pointer(in.DNSLabelName))
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1alpha1_KubeAPIServerService_To_config_KubeAPIServerService is an autogenerated conversion function.
+func Convert_v1alpha1_KubeAPIServerService_To_config_KubeAPIServerService(in *KubeAPIServerService, out *config.KubeAPIServerService, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeAPIServerService_To_config_KubeAPIServerService(in, out, s)
+}
+
+func autoConvert_config_KubeAPIServerService_To_v1alpha1_KubeAPIServerService(in *config.KubeAPIServerService, out *KubeAPIServerService, s conversion.Scope) error {
+	out.IdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.IdleTimeoutInMinutes))
+	out.PublicIPName = (*string)(unsafe.Pointer(in.PublicIPName))
+	out.DNSLabelName = (*string)(unsafe.Pointer(in.DNSLabelName))
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_config_KubeAPIServerService_To_v1alpha1_KubeAPIServerService is an autogenerated conversion function.
+func Convert_config_KubeAPIServerService_To_v1alpha1_KubeAPIServerService(in *config.KubeAPIServerService, out *KubeAPIServerService, s conversion.Scope) error {
+	return autoConvert_config_KubeAPIServerService_To_v1alpha1_KubeAPIServerService(in, out, s)
+}
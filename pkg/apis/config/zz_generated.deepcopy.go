@@ -22,10 +22,27 @@ package config
 
 import (
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACRCredentialProvider) DeepCopyInto(out *ACRCredentialProvider) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACRCredentialProvider.
+func (in *ACRCredentialProvider) DeepCopy() *ACRCredentialProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ACRCredentialProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -41,6 +58,21 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(healthcheckconfig.HealthCheckConfig)
 		**out = **in
 	}
+	if in.KubeAPIServerExposure != nil {
+		in, out := &in.KubeAPIServerExposure, &out.KubeAPIServerExposure
+		*out = new(KubeAPIServerExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeAPIServerService != nil {
+		in, out := &in.KubeAPIServerService, &out.KubeAPIServerService
+		*out = new(KubeAPIServerService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACRCredentialProvider != nil {
+		in, out := &in.ACRCredentialProvider, &out.ACRCredentialProvider
+		*out = new(ACRCredentialProvider)
+		**out = **in
+	}
 	return
 }
 
@@ -126,3 +158,77 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIServerExposure) DeepCopyInto(out *KubeAPIServerExposure) {
+	*out = *in
+	if in.InternalLoadBalancerSubnet != nil {
+		in, out := &in.InternalLoadBalancerSubnet, &out.InternalLoadBalancerSubnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.SeedSecretRef != nil {
+		in, out := &in.SeedSecretRef, &out.SeedSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.SeedResourceGroup != nil {
+		in, out := &in.SeedResourceGroup, &out.SeedResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerExposure.
+func (in *KubeAPIServerExposure) DeepCopy() *KubeAPIServerExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIServerExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIServerService) DeepCopyInto(out *KubeAPIServerService) {
+	*out = *in
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PublicIPName != nil {
+		in, out := &in.PublicIPName, &out.PublicIPName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DNSLabelName != nil {
+		in, out := &in.DNSLabelName, &out.DNSLabelName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceGroup != nil {
+		in, out := &in.ResourceGroup, &out.ResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerService.
+func (in *KubeAPIServerService) DeepCopy() *KubeAPIServerService {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIServerService)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+)
+
+// CountAvailabilitySetVirtualMachines returns the number of virtual machines in the given availability set. It returns
+// zero if the availability set does not exist.
+func CountAvailabilitySetVirtualMachines(ctx context.Context, clientAuth *internal.ClientAuth, resourceGroupName, availabilitySetName string) (int, error) {
+	availabilitySet := struct {
+		Properties struct {
+			VirtualMachines []struct {
+				ID string `json:"id"`
+			} `json:"virtualMachines"`
+		} `json:"properties"`
+	}{}
+
+	if _, err := getResource(ctx, clientAuth, resourceGroupName, "Microsoft.Compute/availabilitySets", availabilitySetName, computeAPIVersion, &availabilitySet); err != nil {
+		return 0, err
+	}
+	return len(availabilitySet.Properties.VirtualMachines), nil
+}
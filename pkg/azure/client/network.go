@@ -0,0 +1,82 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+)
+
+// GetVirtualNetworkPeeringStates returns the states of all peerings of the given virtual network by the names of the peerings.
+func GetVirtualNetworkPeeringStates(ctx context.Context, clientAuth *internal.ClientAuth, resourceGroupName, vnetName string) (map[string]string, error) {
+	vnet := struct {
+		Properties struct {
+			VirtualNetworkPeerings []struct {
+				Name       string `json:"name"`
+				Properties struct {
+					PeeringState string `json:"peeringState"`
+				} `json:"properties"`
+			} `json:"virtualNetworkPeerings"`
+		} `json:"properties"`
+	}{}
+
+	found, err := getResource(ctx, clientAuth, resourceGroupName, "Microsoft.Network/virtualNetworks", vnetName, networkAPIVersion, &vnet)
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[string]string{}
+	if !found {
+		return states, nil
+	}
+	for _, peering := range vnet.Properties.VirtualNetworkPeerings {
+		states[peering.Name] = peering.Properties.PeeringState
+	}
+
+	return states, nil
+}
+
+// GetLoadBalancerSKU returns the SKU of the load balancer with the given name in the given resource group. It returns
+// nil if the load balancer does not exist.
+func GetLoadBalancerSKU(ctx context.Context, clientAuth *internal.ClientAuth, resourceGroupName, loadBalancerName string) (*string, error) {
+	loadBalancer := struct {
+		SKU struct {
+			Name string `json:"name"`
+		} `json:"sku"`
+	}{}
+
+	found, err := getResource(ctx, clientAuth, resourceGroupName, "Microsoft.Network/loadBalancers", loadBalancerName, networkAPIVersion, &loadBalancer)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &loadBalancer.SKU.Name, nil
+}
+
+// GetPrivateLinkServiceAlias returns the alias of the private link service with the given name in the given resource
+// group. It returns nil if the private link service does not exist or has no alias yet.
+func GetPrivateLinkServiceAlias(ctx context.Context, clientAuth *internal.ClientAuth, resourceGroupName, privateLinkServiceName string) (*string, error) {
+	privateLinkService := struct {
+		Properties struct {
+			Alias *string `json:"alias"`
+		} `json:"properties"`
+	}{}
+
+	found, err := getResource(ctx, clientAuth, resourceGroupName, "Microsoft.Network/privateLinkServices", privateLinkServiceName, networkAPIVersion, &privateLinkService)
+	if err != nil || !found {
+		return nil, err
+	}
+	return privateLinkService.Properties.Alias, nil
+}
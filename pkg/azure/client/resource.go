@@ -0,0 +1,87 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+)
+
+const (
+	// computeAPIVersion is the API version used for resources of the Microsoft.Compute provider.
+	computeAPIVersion = "2019-07-01"
+	// networkAPIVersion is the API version used for resources of the Microsoft.Network provider.
+	networkAPIVersion = "2019-06-01"
+)
+
+// getResource gets the Azure resource of the given provider, type and name in the given resource group with the given
+// API version and unmarshals it into the given result. It returns false if the resource does not exist.
+// The resources are read via the generic Azure Resource Manager API, hence, the SDK of the provider is not needed.
+func getResource(ctx context.Context, clientAuth *internal.ClientAuth, resourceGroupName, resourceType, name, apiVersion string, result interface{}) (bool, error) {
+	client := resources.New(clientAuth.SubscriptionID)
+	clientCredConfig := auth.NewClientCredentialsConfig(clientAuth.ClientID, clientAuth.ClientSecret, clientAuth.TenantID)
+	authorizer, err := clientCredConfig.Authorizer()
+	if err != nil {
+		return false, err
+	}
+	client.Authorizer = authorizer
+
+	pathParameters := map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", client.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"resourceType":      resourceType,
+		"name":              autorest.Encode("path", name),
+	}
+	queryParameters := map[string]interface{}{
+		"api-version": apiVersion,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceType}/{name}", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := autorest.SendWithSender(client, req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return false, fmt.Errorf("could not get %s %s/%s: %v", resourceType, resourceGroupName, name, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, autorest.Respond(resp, autorest.ByDiscardingBody(), autorest.ByClosing())
+	}
+
+	if err := autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(result),
+		autorest.ByClosing()); err != nil {
+		return false, fmt.Errorf("could not get %s %s/%s: %v", resourceType, resourceGroupName, name, err)
+	}
+	return true, nil
+}
@@ -33,6 +33,28 @@ const (
 	MachineControllerManagerName = "machine-controller-manager"
 	// CloudControllerManagerImageName is the name of the cloud-controller-manager image.
 	CloudControllerManagerImageName = "cloud-controller-manager"
+	// CSIDriverDiskImageName is the name of the Azure Disk CSI driver image.
+	CSIDriverDiskImageName = "csi-driver-disk"
+	// CSIDriverFileImageName is the name of the Azure File CSI driver image.
+	CSIDriverFileImageName = "csi-driver-file"
+	// CSIProvisionerImageName is the name of the CSI provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSIAttacherImageName is the name of the CSI attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIResizerImageName is the name of the CSI resizer image.
+	CSIResizerImageName = "csi-resizer"
+	// CSISnapshotterImageName is the name of the CSI snapshotter image.
+	CSISnapshotterImageName = "csi-snapshotter"
+	// CSISnapshotControllerImageName is the name of the CSI snapshot controller image.
+	CSISnapshotControllerImageName = "csi-snapshot-controller"
+	// CSINodeDriverRegistrarImageName is the name of the CSI node driver registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
+	// CSILivenessProbeImageName is the name of the CSI liveness probe image.
+	CSILivenessProbeImageName = "csi-liveness-probe"
+	// CloudNodeManagerImageName is the name of the cloud-node-manager image.
+	CloudNodeManagerImageName = "cloud-node-manager"
+	// KMSPluginImageName is the name of the Azure Key Vault KMS plugin image.
+	KMSPluginImageName = "kms-plugin"
 
 	// SubscriptionIDKey is the key for the subscription ID.
 	SubscriptionIDKey = "subscriptionID"
@@ -56,11 +78,11 @@ const (
 	// TODO In the future, the bucket name should come from a BackupBucket resource (see https://github.com/gardener/gardener/blob/master/docs/proposals/02-backupinfra.md)
 	BucketName = "bucketName"
 
-	// CloudProviderConfigName is the name of the configmap containing the cloud provider config.
+	// CloudProviderConfigName is the name of the secret containing the cloud provider config.
 	CloudProviderConfigName = "cloud-provider-config"
 	// CloudProviderKubeletConfigName is the name of the configmap containing the cloud provider config for the shoot nodes.
 	CloudProviderKubeletConfigName = "cloud-provider-kubelet-config"
-	// CloudProviderConfigMapKey is the key storing the cloud provider config as value in the cloud provider configmap.
+	// CloudProviderConfigMapKey is the key storing the cloud provider config as value in the cloud provider config secret and configmap.
 	CloudProviderConfigMapKey = "cloudprovider.conf"
 	// BackupSecretName is the name of the secret containing the credentials for storing the backups of Shoot clusters.
 	BackupSecretName = "etcd-backup"
@@ -70,6 +92,33 @@ const (
 	MachineControllerManagerMonitoringConfigName = "machine-controller-manager-monitoring-config"
 	// CloudControllerManagerName is a constant for the name of the CloudController deployed by the worker controller.
 	CloudControllerManagerName = "cloud-controller-manager"
+	// CloudNodeManagerName is a constant for the name of the cloud-node-manager daemonset in the shoot.
+	CloudNodeManagerName = "cloud-node-manager"
+	// CSIControllerDiskName is a constant for the name of the Azure Disk CSI controller deployment in the seed.
+	CSIControllerDiskName = "csi-driver-controller-disk"
+	// CSINodeDiskName is a constant for the name of the Azure Disk CSI node daemonset in the shoot.
+	CSINodeDiskName = "csi-driver-node-disk"
+	// CSIControllerFileName is a constant for the name of the Azure File CSI controller deployment in the seed.
+	CSIControllerFileName = "csi-driver-controller-file"
+	// CSINodeFileName is a constant for the name of the Azure File CSI node daemonset in the shoot.
+	CSINodeFileName = "csi-driver-node-file"
+	// CSISnapshotControllerName is a constant for the name of the CSI snapshot controller deployment in the shoot.
+	CSISnapshotControllerName = "csi-snapshot-controller"
+	// CSIDiskDriverName is the name of the Azure Disk CSI driver.
+	CSIDiskDriverName = "disk.csi.azure.com"
+	// CSIFileDriverName is the name of the Azure File CSI driver.
+	CSIFileDriverName = "file.csi.azure.com"
+	// AzureDiskProvisionerName is the name of the in-tree Azure Disk volume plugin.
+	AzureDiskProvisionerName = "kubernetes.io/azure-disk"
+	// KMSEncryptionConfigurationName is the name of the secret which contains the encryption configuration of the
+	// kube-apiserver for the encryption of secrets with an Azure Key Vault key.
+	KMSEncryptionConfigurationName = "kms-encryption-configuration"
+	// KMSEncryptionConfigurationKey is the key of the encryption configuration in the secret.
+	KMSEncryptionConfigurationKey = "encryption-configuration.yaml"
+	// KMSProviderNamePrefix is the prefix of the names of the KMS providers of the Azure Key Vault KMS plugins.
+	KMSProviderNamePrefix = "azure-keyvault-"
+	// KMSPluginSocketDir is the directory which contains the sockets of the Azure Key Vault KMS plugins.
+	KMSPluginSocketDir = "/var/run/kmsplugin"
 )
 
 var (
@@ -78,3 +127,20 @@ var (
 	// InternalChartsPath is the path to the internal charts
 	InternalChartsPath = filepath.Join(ChartsPath, "internal")
 )
+
+// KubeAPIServerPrivateLinkServiceName returns the name of the Azure Private Link Service of the kube-apiserver in the
+// given shoot namespace of the seed.
+func KubeAPIServerPrivateLinkServiceName(namespace string) string {
+	return namespace + "-kube-apiserver"
+}
+
+// KMSProviderName returns the name of the KMS provider in the encryption configuration of the kube-apiserver for the
+// given key version. The name is stored with each encrypted secret, hence, it must be unique per key version.
+func KMSProviderName(keyVersion string) string {
+	return KMSProviderNamePrefix + keyVersion
+}
+
+// KMSPluginSocketPath returns the path of the socket of the KMS plugin for the given key version.
+func KMSPluginSocketPath(keyVersion string) string {
+	return filepath.Join(KMSPluginSocketDir, "azurekms-"+keyVersion+".socket")
+}
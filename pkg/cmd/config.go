@@ -76,6 +76,25 @@ func (c *Config) ApplyETCDStorage(etcdStorage *config.ETCDStorage) {
 	*etcdStorage = c.Config.ETCD.Storage
 }
 
+// ApplyKubeAPIServerExposure sets the given kube-apiserver exposure configuration to that of this Config.
+func (c *Config) ApplyKubeAPIServerExposure(exposure *config.KubeAPIServerExposure) {
+	if c.Config.KubeAPIServerExposure != nil {
+		*exposure = *c.Config.KubeAPIServerExposure
+	}
+}
+
+// ApplyKubeAPIServerService sets the given kube-apiserver service configuration to that of this Config.
+func (c *Config) ApplyKubeAPIServerService(service *config.KubeAPIServerService) {
+	if c.Config.KubeAPIServerService != nil {
+		*service = *c.Config.KubeAPIServerService
+	}
+}
+
+// ApplyACRCredentialProvider sets the given image credential provider configuration to that of this Config.
+func (c *Config) ApplyACRCredentialProvider(provider **config.ACRCredentialProvider) {
+	*provider = c.Config.ACRCredentialProvider
+}
+
 // ApplyETCDBackup sets the given etcd backup configuration to that of this Config.
 func (c *Config) ApplyETCDBackup(etcdBackup *config.ETCDBackup) {
 	*etcdBackup = c.Config.ETCD.Backup
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/common"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// actuator wraps the generic controlplane actuator. Besides reconciling the control plane charts, it
+//   - checks whether the Azure Active Directory authentication can be applied (actuator_azuread.go),
+//   - migrates clusters from basic to standard load balancers (actuator_loadbalancer.go),
+//   - deletes the former cloud-provider-config configmap (actuator_cloudprovider.go),
+//   - marks the CSI migration as complete once all kubelets use the CSI drivers (actuator_csi.go),
+//   - rewrites the secrets of the shoot after the key vault key has been rotated (actuator_kms.go) and
+//   - stores the alias of the private link service of the kube-apiserver in the status (actuator_privatelink.go).
+type actuator struct {
+	controlplane.Actuator
+	common.ClientContext
+
+	kubeAPIServerExposure *config.KubeAPIServerExposure
+	logger                logr.Logger
+}
+
+// NewActuator creates a new controlplane actuator which delegates to the given actuator.
+func NewActuator(delegate controlplane.Actuator, kubeAPIServerExposure *config.KubeAPIServerExposure, logger logr.Logger) controlplane.Actuator {
+	return &actuator{
+		Actuator:              delegate,
+		kubeAPIServerExposure: kubeAPIServerExposure,
+		logger:                logger.WithName("azure-controlplane-actuator"),
+	}
+}
+
+// InjectFunc enables injecting Kubernetes dependencies into the delegate actuator.
+func (a *actuator) InjectFunc(f inject.Func) error {
+	return f(a.Actuator)
+}
+
+// Reconcile reconciles the control plane with the generic actuator and performs the Azure specific duties afterwards.
+// It returns true if the control plane must be reconciled again while one of them is still in progress.
+func (a *actuator) Reconcile(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (bool, error) {
+	if err := a.checkAzureAD(ctx, cp, cluster); err != nil {
+		return false, err
+	}
+
+	requeue, err := a.reconcileWithLoadBalancerMigration(ctx, cp, cluster)
+	if err != nil {
+		return requeue, err
+	}
+	if err := a.deleteCloudProviderConfigMap(ctx, cp.Namespace); err != nil {
+		return requeue, err
+	}
+	if err := a.markCSIMigrationComplete(ctx, cp, cluster); err != nil {
+		return requeue, err
+	}
+	kmsRequeue, err := a.reencryptSecrets(ctx, cp, cluster)
+	if err != nil {
+		return requeue, err
+	}
+	requeue = requeue || kmsRequeue
+
+	plsRequeue, err := a.updatePrivateLinkServiceAlias(ctx, cp)
+	if err != nil {
+		return requeue, err
+	}
+	return requeue || plsRequeue, nil
+}
+
+// updateCondition updates the condition of the given type in the status of the control plane.
+func (a *actuator) updateCondition(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, conditionType gardencorev1beta1.ConditionType, status gardencorev1beta1.ConditionStatus, reason, message string) error {
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.Client(), cp, func() error {
+		condition := gardencorev1beta1helper.GetOrInitCondition(cp.Status.Conditions, conditionType)
+		condition = gardencorev1beta1helper.UpdatedCondition(condition, status, reason, message)
+		cp.Status.Conditions = gardencorev1beta1helper.MergeConditions(cp.Status.Conditions, condition)
+		return nil
+	})
+}
+
+// removeCondition returns the given conditions without the condition of the given type.
+func removeCondition(conditions []gardencorev1beta1.Condition, conditionType gardencorev1beta1.ConditionType) []gardencorev1beta1.Condition {
+	var result []gardencorev1beta1.Condition
+	for _, condition := range conditions {
+		if condition.Type != conditionType {
+			result = append(result, condition)
+		}
+	}
+	return result
+}
+
+// isDeploymentRolledOut returns true if all replicas of the given deployment are updated and available.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
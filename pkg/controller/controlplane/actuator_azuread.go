@@ -0,0 +1,84 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConditionTypeAzureADAuthentication is the type of the ControlPlane condition reporting whether the Azure Active
+	// Directory authentication is applied to the kube-apiserver.
+	ConditionTypeAzureADAuthentication gardencorev1beta1.ConditionType = "AzureADAuthentication"
+
+	reasonAzureADConflictingOIDCConfig = "ConflictingOIDCConfig"
+	reasonAzureADTenantMismatch        = "TenantMismatch"
+	reasonAzureADApplied               = "Applied"
+)
+
+// checkAzureAD reports in a condition whether the Azure Active Directory authentication is applied to the
+// kube-apiserver. It is rejected if the shoot configures another OIDC provider. It is not applied if its tenant must
+// but does not match the tenant of the cloud provider credentials, which does not prevent the reconciliation of the
+// remaining control plane.
+func (a *actuator) checkAzureAD(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := a.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
+	if cpConfig.AzureAD == nil {
+		if gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeAzureADAuthentication) == nil {
+			return nil
+		}
+		return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.Client(), cp, func() error {
+			cp.Status.Conditions = removeCondition(cp.Status.Conditions, ConditionTypeAzureADAuthentication)
+			return nil
+		})
+	}
+
+	if internal.HasOIDCConfig(cluster.Shoot) {
+		err := errors.New("the azure active directory authentication cannot be combined with the OIDC configuration of the shoot")
+		if updateErr := a.updateCondition(ctx, cp, ConditionTypeAzureADAuthentication, gardencorev1beta1.ConditionFalse, reasonAzureADConflictingOIDCConfig, err.Error()); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	if validateTenant := cpConfig.AzureAD.ValidateTenant; validateTenant != nil && *validateTenant {
+		clientAuth, err := internal.GetClientAuthData(ctx, a.Client(), cp.Spec.SecretRef)
+		if err != nil {
+			return errors.Wrapf(err, "could not get service account from secret '%s/%s'", cp.Spec.SecretRef.Namespace, cp.Spec.SecretRef.Name)
+		}
+		if !internal.IsAzureADTenantValid(cpConfig.AzureAD, clientAuth) {
+			message := fmt.Sprintf("The azure active directory tenant '%s' does not match the tenant of the cloud provider credentials, the authentication is not applied.", cpConfig.AzureAD.TenantID)
+			return a.updateCondition(ctx, cp, ConditionTypeAzureADAuthentication, gardencorev1beta1.ConditionFalse, reasonAzureADTenantMismatch, message)
+		}
+	}
+	return a.updateCondition(ctx, cp, ConditionTypeAzureADAuthentication, gardencorev1beta1.ConditionTrue, reasonAzureADApplied, "The azure active directory authentication is applied to the kube-apiserver.")
+}
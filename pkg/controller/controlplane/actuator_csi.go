@@ -0,0 +1,97 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// markCSIMigrationComplete annotates the Cluster resource as soon as all kubelets of the shoot use the CSI drivers and
+// updates the control plane deployments afterwards, so that the webhook switches them to the CSI drivers as well. The
+// nodes of new shoots are created with the current Kubernetes version, hence, they are marked right away.
+func (a *actuator) markCSIMigrationComplete(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	csiEnabled, err := internal.IsCSIMigrationEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil || !csiEnabled || extensionscontroller.IsHibernated(cluster) {
+		return err
+	}
+
+	clusterResource := &extensionsv1alpha1.Cluster{}
+	if err := a.Client().Get(ctx, kutil.Key(cp.Namespace), clusterResource); err != nil {
+		return err
+	}
+	if metav1.HasAnnotation(clusterResource.ObjectMeta, internal.AnnotationCSIMigrationComplete) {
+		return nil
+	}
+
+	if lastOperation := cluster.Shoot.Status.LastOperation; lastOperation != nil && lastOperation.Type != gardencorev1beta1.LastOperationTypeCreate {
+		nodeList := &corev1.NodeList{}
+		_, shootClient, err := util.NewClientForShoot(ctx, a.Client(), cp.Namespace, client.Options{})
+		if err == nil {
+			err = shootClient.List(ctx, nodeList)
+		}
+		if err != nil {
+			// The control plane must not fail if the shoot is not reachable, the nodes are checked again with the next
+			// reconciliation.
+			a.logger.Error(err, "could not list nodes of the shoot", "controlplane", util.ObjectName(cp))
+			return nil
+		}
+		for _, node := range nodeList.Items {
+			migrated, err := internal.IsCSIMigrationEnabled(node.Status.NodeInfo.KubeletVersion)
+			if err != nil {
+				return errors.Wrapf(err, "could not check kubelet version of node '%s'", node.Name)
+			}
+			if !migrated {
+				a.logger.Info("Waiting until all kubelets use the CSI drivers", "controlplane", util.ObjectName(cp), "node", node.Name)
+				return nil
+			}
+		}
+	}
+
+	patch := client.MergeFrom(clusterResource.DeepCopy())
+	metav1.SetMetaDataAnnotation(&clusterResource.ObjectMeta, internal.AnnotationCSIMigrationComplete, "true")
+	if err := a.Client().Patch(ctx, clusterResource, patch); err != nil {
+		return errors.Wrap(err, "could not mark CSI migration as complete")
+	}
+
+	// The webhook only mutates the deployments when they are created or updated.
+	for _, name := range []string{v1beta1constants.DeploymentNameKubeAPIServer, v1beta1constants.DeploymentNameKubeControllerManager, v1beta1constants.DeploymentNameKubeScheduler} {
+		deployment := &appsv1.Deployment{}
+		if err := a.Client().Get(ctx, kutil.Key(cp.Namespace, name), deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := a.Client().Update(ctx, deployment); err != nil {
+			return errors.Wrapf(err, "could not update deployment '%s'", name)
+		}
+	}
+	return nil
+}
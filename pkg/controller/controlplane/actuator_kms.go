@@ -0,0 +1,167 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConditionTypeSecretReencryption is the type of the ControlPlane condition reporting the progress of the rewrite
+	// of the shoot secrets after the key vault key has been rotated.
+	ConditionTypeSecretReencryption gardencorev1beta1.ConditionType = "SecretReencryption"
+
+	reasonRewritingSecrets = "RewritingSecrets"
+	reasonSecretsRewritten = "SecretsRewritten"
+
+	// annotationReencryptionContinue is the annotation of the ControlPlane which contains the continue token of the
+	// next batch of shoot secrets which are rewritten with the current key version. It is not stored on the KMS
+	// encryption configuration secret as the secret is overwritten by the controlplane chart.
+	annotationReencryptionContinue = "azure.provider.extensions.gardener.cloud/reencryption-continue"
+	// reencryptionBatchSize is the number of shoot secrets which are rewritten per reconciliation.
+	reencryptionBatchSize = 100
+)
+
+// reencryptSecrets rewrites all secrets of the shoot after the key vault key has been rotated so that they are
+// encrypted with the current key version. The former key versions are dropped from the encryption configuration only
+// afterwards, as secrets which are still encrypted with them could not be decrypted anymore. It returns true while the
+// kube-apiserver is rolled out with the current key version.
+func (a *actuator) reencryptSecrets(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (bool, error) {
+	if extensionscontroller.IsHibernated(cluster) {
+		return false, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := a.Client().Get(ctx, kutil.Key(cp.Namespace, azure.KMSEncryptionConfigurationName), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	keyVersions, err := internal.KMSKeyVersions(secret.Data[azure.KMSEncryptionConfigurationKey])
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get key versions of secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+	if len(keyVersions) < 2 {
+		return false, nil
+	}
+
+	// The secrets are only encrypted with the current key version once all kube-apiservers use it.
+	deployment := &appsv1.Deployment{}
+	if err := a.Client().Get(ctx, kutil.Key(cp.Namespace, v1beta1constants.DeploymentNameKubeAPIServer), deployment); err != nil {
+		return false, err
+	}
+	if deployment.Spec.Template.Annotations["checksum/secret-"+azure.KMSEncryptionConfigurationName] != util.ComputeChecksum(secret.Data) {
+		// The webhook only mutates the deployment when it is created or updated.
+		if err := a.Client().Update(ctx, deployment); err != nil {
+			return false, errors.Wrapf(err, "could not update deployment '%s'", v1beta1constants.DeploymentNameKubeAPIServer)
+		}
+		return true, nil
+	}
+	if !isDeploymentRolledOut(deployment) {
+		return true, nil
+	}
+
+	a.logger.Info("Rewriting secrets with the current key version", "controlplane", util.ObjectName(cp), "keyVersion", keyVersions[0])
+	_, shootClient, err := util.NewClientForShoot(ctx, a.Client(), cp.Namespace, client.Options{})
+	if err != nil {
+		return false, errors.Wrap(err, "could not create shoot client")
+	}
+	continueToken, err := reencryptSecretBatch(ctx, shootClient, cp.Annotations[annotationReencryptionContinue])
+	if err != nil {
+		return false, err
+	}
+	if err := a.updateReencryptionContinueToken(ctx, cp, continueToken); err != nil {
+		return false, err
+	}
+	if len(continueToken) > 0 {
+		if err := a.updateCondition(ctx, cp, ConditionTypeSecretReencryption, gardencorev1beta1.ConditionProgressing, reasonRewritingSecrets, fmt.Sprintf("Rewriting the secrets with key version %s.", keyVersions[0])); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	encryptionConfiguration, err := internal.KMSEncryptionConfiguration(keyVersions[:1], secret.Data[azure.KMSEncryptionConfigurationKey])
+	if err != nil {
+		return false, err
+	}
+	secret.Data[azure.KMSEncryptionConfigurationKey] = []byte(encryptionConfiguration)
+	if err := a.Client().Update(ctx, secret); err != nil {
+		return false, errors.Wrapf(err, "could not drop former key versions from secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+
+	// Remove the KMS plugins of the former key versions from the kube-apiserver.
+	if err := a.Client().Update(ctx, deployment); err != nil {
+		return false, errors.Wrapf(err, "could not update deployment '%s'", v1beta1constants.DeploymentNameKubeAPIServer)
+	}
+	return false, a.updateCondition(ctx, cp, ConditionTypeSecretReencryption, gardencorev1beta1.ConditionTrue, reasonSecretsRewritten, fmt.Sprintf("All secrets are encrypted with key version %s.", keyVersions[0]))
+}
+
+// reencryptSecretBatch rewrites the next batch of secrets of the shoot starting at the given continue token. It returns
+// the continue token of the following batch or an empty string if all secrets have been rewritten. An expired continue
+// token restarts the rewrite with the first batch.
+func reencryptSecretBatch(ctx context.Context, shootClient client.Client, continueToken string) (string, error) {
+	secretList := &corev1.SecretList{}
+	if err := shootClient.List(ctx, secretList, client.Limit(reencryptionBatchSize), client.Continue(continueToken)); err != nil {
+		if apierrors.IsResourceExpired(err) && len(continueToken) > 0 {
+			return reencryptSecretBatch(ctx, shootClient, "")
+		}
+		return "", errors.Wrap(err, "could not list secrets of the shoot")
+	}
+	for i := range secretList.Items {
+		// Secrets which are updated without changes are encrypted with the first provider of the encryption
+		// configuration. Secrets which have been updated or deleted in the meantime are encrypted with it already.
+		if err := shootClient.Update(ctx, &secretList.Items[i]); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "could not rewrite secret '%s'", util.ObjectName(&secretList.Items[i]))
+		}
+	}
+	return secretList.Continue, nil
+}
+
+// updateReencryptionContinueToken records the continue token of the next batch of shoot secrets in the annotations of
+// the control plane, so that the rewrite continues with this batch after a restart of the controller. An empty token
+// removes the annotation.
+func (a *actuator) updateReencryptionContinueToken(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, continueToken string) error {
+	if cp.Annotations[annotationReencryptionContinue] == continueToken {
+		return nil
+	}
+	patch := client.MergeFrom(cp.DeepCopy())
+	if len(continueToken) > 0 {
+		metav1.SetMetaDataAnnotation(&cp.ObjectMeta, annotationReencryptionContinue, continueToken)
+	} else {
+		delete(cp.Annotations, annotationReencryptionContinue)
+	}
+	if err := a.Client().Patch(ctx, cp, patch); err != nil {
+		return errors.Wrapf(err, "could not record the progress of the rewrite in controlplane '%s'", util.ObjectName(cp))
+	}
+	return nil
+}
@@ -0,0 +1,465 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ConditionTypeLoadBalancerMigration is the type of the ControlPlane condition reporting the progress of the
+	// migration from basic to standard load balancers.
+	ConditionTypeLoadBalancerMigration gardencorev1beta1.ConditionType = "LoadBalancerMigration"
+
+	// The reasons of the load balancer migration condition are also used to track the phase of the migration.
+	reasonDeletingServices       = "DeletingServices"
+	reasonDeletingSystemServices = "DeletingSystemServices"
+	reasonRecreatingServices     = "RecreatingServices"
+	reasonMigrated               = "Migrated"
+
+	// loadBalancerMigrationBackupName is the name of the configmap in the shoot namespace of the seed which holds the
+	// services of type LoadBalancer while they are migrated.
+	loadBalancerMigrationBackupName = "load-balancer-migration-backup"
+	// annotationSystemServices is the annotation of the backup which contains the UIDs of the system services which
+	// are deleted after the switch to standard load balancers.
+	annotationSystemServices = "azure.provider.extensions.gardener.cloud/system-services"
+	// annotationReleasedLoadBalancerIPs is the annotation of the backup which contains the static load balancer IPs
+	// which have been dropped from the backed up services.
+	annotationReleasedLoadBalancerIPs = "azure.provider.extensions.gardener.cloud/released-load-balancer-ips"
+
+	// managedResourceOriginAnnotation is set by the gardener-resource-manager on all objects of managed resources.
+	managedResourceOriginAnnotation = "resources.gardener.cloud/origin"
+)
+
+// reconcileWithLoadBalancerMigration reconciles the control plane with the generic actuator. If the migration to
+// standard load balancers is requested, it deletes the services of type LoadBalancer before the cloud-controller-manager
+// is switched to standard load balancers and recreates them afterwards.
+func (a *actuator) reconcileWithLoadBalancerMigration(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (bool, error) {
+	// The services of hibernated clusters cannot be migrated, the migration continues as soon as the cluster is woken up.
+	if extensionscontroller.IsHibernated(cluster) {
+		return a.Actuator.Reconcile(ctx, cp, cluster)
+	}
+
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := a.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return false, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+	infraStatus := &apisazure.InfrastructureStatus{}
+	if _, _, err := a.Decoder().Decode(cp.Spec.InfrastructureProviderStatus.Raw, nil, infraStatus); err != nil {
+		return false, errors.Wrapf(err, "could not decode infrastructureProviderStatus of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	if !needsLoadBalancerMigration(cpConfig, infraStatus, cp) {
+		return a.Actuator.Reconcile(ctx, cp, cluster)
+	}
+
+	// Nothing has to be migrated if the cluster has no basic load balancers, e.g. if it has no services of type
+	// LoadBalancer yet or if the condition of a finished migration got lost.
+	if gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeLoadBalancerMigration) == nil {
+		exists, err := a.basicLoadBalancerExists(ctx, cp, infraStatus)
+		if err != nil {
+			return false, errors.Wrap(err, "could not check for basic load balancers")
+		}
+		if !exists {
+			if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionTrue, reasonMigrated, "The cluster has no basic load balancers, new services use standard load balancers."); err != nil {
+				return false, err
+			}
+			return a.Actuator.Reconcile(ctx, cp, cluster)
+		}
+		if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionProgressing, reasonDeletingServices, "Waiting until all services of type LoadBalancer are deleted."); err != nil {
+			return false, err
+		}
+	}
+
+	_, shootClient, err := util.NewClientForShoot(ctx, a.Client(), cp.Namespace, client.Options{})
+	if err != nil {
+		return false, errors.Wrap(err, "could not create shoot client")
+	}
+
+	// The services of the system components are recreated by their owners as soon as they are deleted, hence, they
+	// would get basic load balancers again. Therefore, only the other services are deleted before the
+	// cloud-controller-manager is switched to standard load balancers, the system services are deleted afterwards.
+	if !isLoadBalancerSKUSwitched(cpConfig, cp) {
+		deleted, err := a.deleteLoadBalancerServices(ctx, cp, shootClient)
+		if err != nil {
+			return false, a.loadBalancerMigrationFailed(ctx, cp, reasonDeletingServices, err)
+		}
+		if !deleted {
+			if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionProgressing, reasonDeletingServices, "Waiting until all services of type LoadBalancer are deleted."); err != nil {
+				return false, err
+			}
+			if _, err := a.Actuator.Reconcile(ctx, cp, cluster); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+
+		if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionProgressing, reasonDeletingSystemServices, "Switched to standard load balancers, waiting until the services of the system components are moved to standard load balancers."); err != nil {
+			return false, err
+		}
+	}
+
+	requeue, err := a.Actuator.Reconcile(ctx, cp, cluster)
+	if err != nil {
+		return requeue, err
+	}
+
+	if condition := gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeLoadBalancerMigration); condition != nil && condition.Reason == reasonDeletingSystemServices {
+		// The services must not be deleted before the cloud-controller-manager uses the new configuration, otherwise
+		// basic load balancers would be created again.
+		rolledOut, err := a.isCloudControllerManagerRolledOut(ctx, cp.Namespace)
+		if err != nil {
+			return false, a.loadBalancerMigrationFailed(ctx, cp, reasonDeletingSystemServices, err)
+		}
+		if !rolledOut {
+			return true, nil
+		}
+
+		// The backed up services can only be recreated once the basic load balancers are gone as the standard load
+		// balancers get the same names.
+		deleted, err := a.deleteSystemLoadBalancerServices(ctx, cp, infraStatus, shootClient)
+		if err != nil {
+			return false, a.loadBalancerMigrationFailed(ctx, cp, reasonDeletingSystemServices, err)
+		}
+		if !deleted {
+			return true, nil
+		}
+
+		if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionProgressing, reasonRecreatingServices, "All basic load balancers are deleted, the services are recreated."); err != nil {
+			return false, err
+		}
+	}
+
+	releasedIPs, err := recreateLoadBalancerServices(ctx, a.Client(), shootClient, cp.Namespace, a.logger)
+	if err != nil {
+		return false, a.loadBalancerMigrationFailed(ctx, cp, reasonRecreatingServices, err)
+	}
+
+	message := "All services of type LoadBalancer have been migrated to standard load balancers."
+	if len(releasedIPs) > 0 {
+		message += fmt.Sprintf(" The static load balancer IPs of the following services have been released as basic public IPs cannot be used by standard load balancers: %s.", strings.Join(releasedIPs, ", "))
+	}
+	if err := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionTrue, reasonMigrated, message); err != nil {
+		return false, err
+	}
+	return requeue, nil
+}
+
+// deleteLoadBalancerServices backs up and deletes the services of type LoadBalancer of the shoot which do not belong to
+// the system components. The system services are recorded in the backup, so that they can be deleted after the switch
+// to standard load balancers. It returns true as soon as the deleted services are gone.
+func (a *actuator) deleteLoadBalancerServices(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, shootClient client.Client) (bool, error) {
+	services, err := getLoadBalancerServices(ctx, shootClient)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		userServices      []corev1.Service
+		systemServiceUIDs []string
+	)
+	for _, service := range services {
+		if isSystemService(&service) {
+			systemServiceUIDs = append(systemServiceUIDs, string(service.UID))
+			continue
+		}
+		userServices = append(userServices, service)
+	}
+
+	backup := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: loadBalancerMigrationBackupName, Namespace: cp.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.Client(), backup, func() error {
+		if backup.Annotations == nil {
+			backup.Annotations = map[string]string{}
+		}
+		backup.Annotations[annotationSystemServices] = strings.Join(systemServiceUIDs, ",")
+		return backupServices(backup, userServices)
+	}); err != nil {
+		return false, errors.Wrap(err, "could not backup services of type LoadBalancer")
+	}
+
+	for _, service := range userServices {
+		if service.DeletionTimestamp != nil {
+			continue
+		}
+		a.logger.Info("Deleting service for load balancer migration", "controlplane", util.ObjectName(cp), "service", util.ObjectName(&service))
+		if err := shootClient.Delete(ctx, service.DeepCopy()); client.IgnoreNotFound(err) != nil {
+			return false, errors.Wrapf(err, "could not delete service '%s'", util.ObjectName(&service))
+		}
+	}
+	return len(userServices) == 0, nil
+}
+
+// deleteSystemLoadBalancerServices deletes the system services recorded in the backup, their owners recreate them with
+// standard load balancers. It returns true as soon as the recorded services and the basic load balancers are gone.
+func (a *actuator) deleteSystemLoadBalancerServices(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, infraStatus *apisazure.InfrastructureStatus, shootClient client.Client) (bool, error) {
+	backup := &corev1.ConfigMap{}
+	if err := a.Client().Get(ctx, kutil.Key(cp.Namespace, loadBalancerMigrationBackupName), backup); client.IgnoreNotFound(err) != nil {
+		return false, errors.Wrap(err, "could not read backup of services of type LoadBalancer")
+	}
+	systemServiceUIDs := sets.NewString()
+	if uids := backup.Annotations[annotationSystemServices]; len(uids) > 0 {
+		systemServiceUIDs.Insert(strings.Split(uids, ",")...)
+	}
+
+	services, err := getLoadBalancerServices(ctx, shootClient)
+	if err != nil {
+		return false, err
+	}
+
+	deleted := true
+	for _, service := range services {
+		// Services which have been recreated by their owners have new UIDs.
+		if !systemServiceUIDs.Has(string(service.UID)) {
+			continue
+		}
+		deleted = false
+		if service.DeletionTimestamp != nil {
+			continue
+		}
+		a.logger.Info("Deleting system service for load balancer migration", "controlplane", util.ObjectName(cp), "service", util.ObjectName(&service))
+		if err := shootClient.Delete(ctx, service.DeepCopy()); client.IgnoreNotFound(err) != nil {
+			return false, errors.Wrapf(err, "could not delete service '%s'", util.ObjectName(&service))
+		}
+	}
+	if !deleted {
+		return false, nil
+	}
+
+	exists, err := a.basicLoadBalancerExists(ctx, cp, infraStatus)
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// basicLoadBalancerExists returns true if one of the load balancers of the cluster has the basic SKU.
+func (a *actuator) basicLoadBalancerExists(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, infraStatus *apisazure.InfrastructureStatus) (bool, error) {
+	clientAuth, err := internal.GetClientAuthData(ctx, a.Client(), cp.Spec.SecretRef)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get service account from secret '%s/%s'", cp.Spec.SecretRef.Namespace, cp.Spec.SecretRef.Name)
+	}
+
+	// The cloud-controller-manager names the load balancers after the cluster.
+	for _, loadBalancerName := range []string{cp.Namespace, cp.Namespace + "-internal"} {
+		sku, err := azureclient.GetLoadBalancerSKU(ctx, clientAuth, infraStatus.ResourceGroup.Name, loadBalancerName)
+		if err != nil {
+			return false, errors.Wrapf(err, "could not get load balancer '%s'", loadBalancerName)
+		}
+		if sku != nil && strings.EqualFold(*sku, string(apisazure.LoadBalancerSKUBasic)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isCloudControllerManagerRolledOut returns true if all replicas of the cloud-controller-manager deployment are
+// updated and available.
+func (a *actuator) isCloudControllerManagerRolledOut(ctx context.Context, namespace string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := a.Client().Get(ctx, kutil.Key(namespace, cloudControllerManagerDeploymentName), deployment); err != nil {
+		return false, err
+	}
+
+	return isDeploymentRolledOut(deployment), nil
+}
+
+func (a *actuator) loadBalancerMigrationFailed(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, reason string, err error) error {
+	if updateErr := a.updateLoadBalancerMigrationCondition(ctx, cp, gardencorev1beta1.ConditionFalse, reason, err.Error()); updateErr != nil {
+		return updateErr
+	}
+	return errors.Wrap(err, "could not migrate to standard load balancers")
+}
+
+func (a *actuator) updateLoadBalancerMigrationCondition(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, status gardencorev1beta1.ConditionStatus, reason, message string) error {
+	return a.updateCondition(ctx, cp, ConditionTypeLoadBalancerMigration, status, reason, message)
+}
+
+// recreateLoadBalancerServices creates the services of type LoadBalancer from the backup and deletes the backup
+// afterwards. It returns the static load balancer IPs which have been released by the migration.
+func recreateLoadBalancerServices(ctx context.Context, seedClient, shootClient client.Client, namespace string, logger logr.Logger) ([]string, error) {
+	backup := &corev1.ConfigMap{}
+	if err := seedClient.Get(ctx, kutil.Key(namespace, loadBalancerMigrationBackupName), backup); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	keys := make([]string, 0, len(backup.Data))
+	for key := range backup.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		service := &corev1.Service{}
+		if err := json.Unmarshal([]byte(backup.Data[key]), service); err != nil {
+			return nil, errors.Wrapf(err, "could not decode backup of service '%s'", key)
+		}
+		logger.Info("Recreating service for load balancer migration", "namespace", namespace, "service", util.ObjectName(service))
+		if err := shootClient.Create(ctx, service); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, errors.Wrapf(err, "could not recreate service '%s'", util.ObjectName(service))
+		}
+	}
+
+	var releasedIPs []string
+	if ips := backup.Annotations[annotationReleasedLoadBalancerIPs]; len(ips) > 0 {
+		releasedIPs = strings.Split(ips, ",")
+	}
+	return releasedIPs, client.IgnoreNotFound(seedClient.Delete(ctx, backup))
+}
+
+// getLoadBalancerServices returns the services of type LoadBalancer of the shoot sorted by namespace and name.
+func getLoadBalancerServices(ctx context.Context, shootClient client.Client) ([]corev1.Service, error) {
+	serviceList := &corev1.ServiceList{}
+	if err := shootClient.List(ctx, serviceList); err != nil {
+		return nil, errors.Wrap(err, "could not list services")
+	}
+
+	var services []corev1.Service
+	for _, service := range serviceList.Items {
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			services = append(services, service)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return serviceKey(&services[i]) < serviceKey(&services[j])
+	})
+	return services, nil
+}
+
+// backupServices adds the given services to the backup configmap unless they are already contained. Fields which are
+// allocated by the API server are dropped, so that the services can be created again. Static load balancer IPs are
+// dropped as well because they are basic public IPs which cannot be used by standard load balancers, they are recorded
+// in the annotations of the backup.
+func backupServices(backup *corev1.ConfigMap, services []corev1.Service) error {
+	if backup.Data == nil {
+		backup.Data = map[string]string{}
+	}
+
+	var releasedIPs []string
+	if ips := backup.Annotations[annotationReleasedLoadBalancerIPs]; len(ips) > 0 {
+		releasedIPs = strings.Split(ips, ",")
+	}
+
+	for _, service := range services {
+		key := serviceKey(&service)
+		if _, ok := backup.Data[key]; ok {
+			continue
+		}
+
+		serviceBackup := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        service.Name,
+				Namespace:   service.Namespace,
+				Labels:      service.Labels,
+				Annotations: service.Annotations,
+			},
+			Spec: *service.Spec.DeepCopy(),
+		}
+		serviceBackup.Spec.ClusterIP = ""
+		serviceBackup.Spec.HealthCheckNodePort = 0
+		for i := range serviceBackup.Spec.Ports {
+			serviceBackup.Spec.Ports[i].NodePort = 0
+		}
+		if ip := serviceBackup.Spec.LoadBalancerIP; len(ip) > 0 {
+			releasedIPs = append(releasedIPs, fmt.Sprintf("%s/%s (%s)", service.Namespace, service.Name, ip))
+			serviceBackup.Spec.LoadBalancerIP = ""
+		}
+
+		data, err := json.Marshal(serviceBackup)
+		if err != nil {
+			return err
+		}
+		backup.Data[key] = string(data)
+	}
+
+	if len(releasedIPs) > 0 {
+		if backup.Annotations == nil {
+			backup.Annotations = map[string]string{}
+		}
+		backup.Annotations[annotationReleasedLoadBalancerIPs] = strings.Join(releasedIPs, ",")
+	}
+	return nil
+}
+
+// isSystemService returns true if the given service belongs to the system components of the shoot, e.g. the vpn-shoot
+// service. Such services are recreated by their owners as soon as they are deleted.
+func isSystemService(service *corev1.Service) bool {
+	_, managed := service.Annotations[managedResourceOriginAnnotation]
+	return managed || service.Namespace == metav1.NamespaceSystem
+}
+
+// serviceKey returns the key of the given service in the backup configmap. Namespaces and service names are DNS
+// labels, hence, the key is unique.
+func serviceKey(service *corev1.Service) string {
+	return fmt.Sprintf("%s.%s", service.Namespace, service.Name)
+}
+
+// needsLoadBalancerMigration returns true if the cluster uses basic load balancers and the migration to standard load
+// balancers has been requested or is already in progress.
+func needsLoadBalancerMigration(cpConfig *apisazure.ControlPlaneConfig, infraStatus *apisazure.InfrastructureStatus, cp *extensionsv1alpha1.ControlPlane) bool {
+	if !usesBasicLoadBalancer(infraStatus) {
+		return false
+	}
+	if condition := gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeLoadBalancerMigration); condition != nil {
+		return condition.Reason != reasonMigrated
+	}
+	return cpConfig.LoadBalancerSKU != nil && *cpConfig.LoadBalancerSKU == apisazure.LoadBalancerSKUStandard
+}
+
+// isLoadBalancerSKUSwitched returns true if the cloud-controller-manager has to use standard load balancers. While the
+// migration is in progress, this depends on its phase, otherwise on the configured load balancer SKU.
+func isLoadBalancerSKUSwitched(cpConfig *apisazure.ControlPlaneConfig, cp *extensionsv1alpha1.ControlPlane) bool {
+	if condition := gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypeLoadBalancerMigration); condition != nil && condition.Reason != reasonMigrated {
+		return condition.Reason == reasonDeletingSystemServices || condition.Reason == reasonRecreatingServices
+	}
+	return cpConfig.LoadBalancerSKU != nil && *cpConfig.LoadBalancerSKU == apisazure.LoadBalancerSKUStandard
+}
+
+// usesBasicLoadBalancer returns true if the cloud-controller-manager of the given infrastructure uses basic load
+// balancers by default. This is only the case for non-zoned single-stack clusters with one availability set.
+func usesBasicLoadBalancer(infraStatus *apisazure.InfrastructureStatus) bool {
+	if infraStatus.Zoned || hasWorkerPoolAvailabilitySets(infraStatus) {
+		return false
+	}
+	nodesSubnet, err := azureapihelper.FindSubnetByPurpose(infraStatus.Networks.Subnets, apisazure.PurposeNodes)
+	return err != nil || !nodesSubnet.DualStack
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azurev1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConditionTypePrivateLinkService is the type of the ControlPlane condition reporting whether the alias of the
+	// Private Link Service of the kube-apiserver is available.
+	ConditionTypePrivateLinkService gardencorev1beta1.ConditionType = "PrivateLinkService"
+
+	reasonPrivateLinkServiceMisconfigured = "Misconfigured"
+	reasonPrivateLinkServiceFailed        = "LookupFailed"
+	reasonPrivateLinkServicePending       = "Pending"
+	reasonPrivateLinkServiceAvailable     = "Available"
+)
+
+// getPrivateLinkServiceAlias returns the alias of a private link service. It is a variable, so that it can be replaced
+// in tests.
+var getPrivateLinkServiceAlias = azureclient.GetPrivateLinkServiceAlias
+
+// updatePrivateLinkServiceAlias stores the alias of the private link service of the kube-apiserver in the status of the
+// control plane. It returns true as long as the private link service has not been created yet, so that the control
+// plane is reconciled again without failing the operation.
+func (a *actuator) updatePrivateLinkServiceAlias(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) (bool, error) {
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := a.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return false, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
+	if cpConfig.PrivateLinkService == nil {
+		if cp.Status.ProviderStatus == nil && gardencorev1beta1helper.GetCondition(cp.Status.Conditions, ConditionTypePrivateLinkService) == nil {
+			return false, nil
+		}
+		return false, extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.Client(), cp, func() error {
+			cp.Status.ProviderStatus = nil
+			cp.Status.Conditions = removeCondition(cp.Status.Conditions, ConditionTypePrivateLinkService)
+			return nil
+		})
+	}
+
+	if a.kubeAPIServerExposure == nil || a.kubeAPIServerExposure.SeedSecretRef == nil || a.kubeAPIServerExposure.SeedResourceGroup == nil {
+		return false, a.privateLinkServiceFailed(ctx, cp, reasonPrivateLinkServiceMisconfigured, errors.New("the extension is not configured with credentials and the resource group of the seed"))
+	}
+
+	clientAuth, err := internal.GetClientAuthData(ctx, a.Client(), *a.kubeAPIServerExposure.SeedSecretRef)
+	if err != nil {
+		return false, a.privateLinkServiceFailed(ctx, cp, reasonPrivateLinkServiceMisconfigured, errors.Wrapf(err, "could not get service account from secret '%s/%s'", a.kubeAPIServerExposure.SeedSecretRef.Namespace, a.kubeAPIServerExposure.SeedSecretRef.Name))
+	}
+
+	alias, err := getPrivateLinkServiceAlias(ctx, clientAuth, *a.kubeAPIServerExposure.SeedResourceGroup, azure.KubeAPIServerPrivateLinkServiceName(cp.Namespace))
+	if err != nil {
+		return false, a.privateLinkServiceFailed(ctx, cp, reasonPrivateLinkServiceFailed, err)
+	}
+	if alias == nil {
+		if err := a.updateCondition(ctx, cp, ConditionTypePrivateLinkService, gardencorev1beta1.ConditionProgressing, reasonPrivateLinkServicePending, "Waiting until the private link service of the kube-apiserver is created."); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.Client(), cp, func() error {
+		cp.Status.ProviderStatus = &runtime.RawExtension{Object: &azurev1alpha1.ControlPlaneStatus{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: azurev1alpha1.SchemeGroupVersion.String(),
+				Kind:       "ControlPlaneStatus",
+			},
+			PrivateLinkServiceAlias: alias,
+		}}
+		condition := gardencorev1beta1helper.GetOrInitCondition(cp.Status.Conditions, ConditionTypePrivateLinkService)
+		condition = gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, reasonPrivateLinkServiceAvailable, "The alias of the private link service of the kube-apiserver is available.")
+		cp.Status.Conditions = gardencorev1beta1helper.MergeConditions(cp.Status.Conditions, condition)
+		return nil
+	})
+}
+
+func (a *actuator) privateLinkServiceFailed(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, reason string, err error) error {
+	if updateErr := a.updateCondition(ctx, cp, ConditionTypePrivateLinkService, gardencorev1beta1.ConditionFalse, reason, err.Error()); updateErr != nil {
+		return updateErr
+	}
+	return errors.Wrap(err, "could not get private link service of the kube-apiserver")
+}
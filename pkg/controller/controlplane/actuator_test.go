@@ -0,0 +1,590 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/common"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Actuator", func() {
+	var (
+		ctrl *gomock.Controller
+
+		standard = apisazure.LoadBalancerSKUStandard
+
+		service = corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "ingress",
+				Namespace:       "default",
+				Annotations:     map[string]string{"foo": "bar"},
+				ResourceVersion: "42",
+			},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeLoadBalancer,
+				ClusterIP: "10.0.0.10",
+				Ports:     []corev1.ServicePort{{Port: 443, NodePort: 30443}},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	DescribeTable("#needsLoadBalancerMigration",
+		func(sku *apisazure.LoadBalancerSKU, zoned bool, reason string, expected bool) {
+			cp := &extensionsv1alpha1.ControlPlane{}
+			if reason != "" {
+				cp.Status.Conditions = []gardencorev1beta1.Condition{{Type: ConditionTypeLoadBalancerMigration, Reason: reason}}
+			}
+			infraStatus := &apisazure.InfrastructureStatus{Zoned: zoned}
+
+			Expect(needsLoadBalancerMigration(&apisazure.ControlPlaneConfig{LoadBalancerSKU: sku}, infraStatus, cp)).To(Equal(expected))
+		},
+		Entry("no sku", nil, false, "", false),
+		Entry("standard sku", &standard, false, "", true),
+		Entry("standard sku in zoned cluster", &standard, true, "", false),
+		Entry("migration in progress", nil, false, reasonDeletingServices, true),
+		Entry("migration finished", &standard, false, reasonMigrated, false),
+	)
+
+	DescribeTable("#isLoadBalancerSKUSwitched",
+		func(sku *apisazure.LoadBalancerSKU, reason string, expected bool) {
+			cp := &extensionsv1alpha1.ControlPlane{}
+			if reason != "" {
+				cp.Status.Conditions = []gardencorev1beta1.Condition{{Type: ConditionTypeLoadBalancerMigration, Reason: reason}}
+			}
+
+			Expect(isLoadBalancerSKUSwitched(&apisazure.ControlPlaneConfig{LoadBalancerSKU: sku}, cp)).To(Equal(expected))
+		},
+		Entry("no sku", nil, "", false),
+		Entry("standard sku", &standard, "", true),
+		Entry("deleting services", &standard, reasonDeletingServices, false),
+		Entry("deleting system services", &standard, reasonDeletingSystemServices, true),
+		Entry("recreating services", &standard, reasonRecreatingServices, true),
+		Entry("migration finished", &standard, reasonMigrated, true),
+	)
+
+	DescribeTable("#isSystemService",
+		func(namespace string, annotations map[string]string, expected bool) {
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Annotations: annotations}}
+
+			Expect(isSystemService(service)).To(Equal(expected))
+		},
+		Entry("service of the user", "default", nil, false),
+		Entry("service in kube-system", "kube-system", nil, true),
+		Entry("service of a managed resource", "default", map[string]string{managedResourceOriginAnnotation: "shoot--foo--bar/addons"}, true),
+	)
+
+	Describe("#backupServices", func() {
+		It("should drop the allocated fields and keep existing backups", func() {
+			backup := &corev1.ConfigMap{Data: map[string]string{"kube-system.nginx": "{}"}}
+			existing := service.DeepCopy()
+			existing.Name, existing.Namespace = "nginx", "kube-system"
+
+			Expect(backupServices(backup, []corev1.Service{service, *existing})).To(Succeed())
+			Expect(backup.Data).To(HaveKeyWithValue("kube-system.nginx", "{}"))
+
+			serviceBackup := &corev1.Service{}
+			Expect(json.Unmarshal([]byte(backup.Data["default.ingress"]), serviceBackup)).To(Succeed())
+			Expect(serviceBackup.Name).To(Equal("ingress"))
+			Expect(serviceBackup.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(serviceBackup.ResourceVersion).To(BeEmpty())
+			Expect(serviceBackup.Spec.ClusterIP).To(BeEmpty())
+			Expect(serviceBackup.Spec.Ports).To(Equal([]corev1.ServicePort{{Port: 443}}))
+			Expect(backup.Annotations).NotTo(HaveKey(annotationReleasedLoadBalancerIPs))
+		})
+
+		It("should drop and record static load balancer IPs", func() {
+			backup := &corev1.ConfigMap{}
+			static := service.DeepCopy()
+			static.Spec.LoadBalancerIP = "1.2.3.4"
+
+			Expect(backupServices(backup, []corev1.Service{*static})).To(Succeed())
+
+			serviceBackup := &corev1.Service{}
+			Expect(json.Unmarshal([]byte(backup.Data["default.ingress"]), serviceBackup)).To(Succeed())
+			Expect(serviceBackup.Spec.LoadBalancerIP).To(BeEmpty())
+			Expect(backup.Annotations).To(HaveKeyWithValue(annotationReleasedLoadBalancerIPs, "default/ingress (1.2.3.4)"))
+		})
+	})
+
+	Describe("#deleteCloudProviderConfigMap", func() {
+		var (
+			kubeAPIServerKey         = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer}
+			kubeControllerManagerKey = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeControllerManager}
+			configMap                = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: azure.CloudProviderConfigName, Namespace: namespace}}
+		)
+
+		deploymentWithVolume := func(volumeSource corev1.VolumeSource) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{{Name: azure.CloudProviderConfigName, VolumeSource: volumeSource}},
+						},
+					},
+				},
+			}
+		}
+
+		It("should delete the configmap if it is not mounted anymore", func() {
+			secretVolume := corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: azure.CloudProviderConfigName}}
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(deploymentGet(deploymentWithVolume(secretVolume)))
+			c.EXPECT().Get(context.TODO(), kubeControllerManagerKey, &appsv1.Deployment{}).Return(apierrors.NewNotFound(schema.GroupResource{}, v1beta1constants.DeploymentNameKubeControllerManager))
+			c.EXPECT().Delete(context.TODO(), configMap)
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.deleteCloudProviderConfigMap(context.TODO(), namespace)).To(Succeed())
+		})
+
+		It("should keep the configmap as long as it is mounted", func() {
+			configMapVolume := corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: azure.CloudProviderConfigName}}}
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(deploymentGet(deploymentWithVolume(configMapVolume)))
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.deleteCloudProviderConfigMap(context.TODO(), namespace)).To(Succeed())
+		})
+	})
+
+	Describe("#markCSIMigrationComplete", func() {
+		clusterWithVersion := func(version string) *extensionscontroller.Cluster {
+			return &extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{Version: version},
+					},
+				},
+			}
+		}
+
+		It("should do nothing for shoots without CSI migration", func() {
+			a := &actuator{ClientContext: common.NewClientContext(mockclient.NewMockClient(ctrl), nil, nil)}
+			Expect(a.markCSIMigrationComplete(context.TODO(), &extensionsv1alpha1.ControlPlane{}, clusterWithVersion("1.20.4"))).To(Succeed())
+		})
+
+		It("should do nothing if the cluster is already marked", func() {
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				obj.(*extensionsv1alpha1.Cluster).Annotations = map[string]string{internal.AnnotationCSIMigrationComplete: "true"}
+				return nil
+			})
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			cp := &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+			Expect(a.markCSIMigrationComplete(context.TODO(), cp, clusterWithVersion("1.21.0"))).To(Succeed())
+		})
+
+		It("should mark new shoots and update the control plane deployments", func() {
+			c := mockclient.NewMockClient(ctrl)
+			gomock.InOrder(
+				c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}),
+				c.EXPECT().Patch(context.TODO(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+					Expect(obj.(*extensionsv1alpha1.Cluster).Annotations).To(HaveKeyWithValue(internal.AnnotationCSIMigrationComplete, "true"))
+					return nil
+				}),
+			)
+			for _, name := range []string{v1beta1constants.DeploymentNameKubeAPIServer, v1beta1constants.DeploymentNameKubeControllerManager} {
+				c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, &appsv1.Deployment{})
+			}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeScheduler}, &appsv1.Deployment{}).Return(apierrors.NewNotFound(schema.GroupResource{}, v1beta1constants.DeploymentNameKubeScheduler))
+			c.EXPECT().Update(context.TODO(), &appsv1.Deployment{}).Times(2)
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			cp := &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+			Expect(a.markCSIMigrationComplete(context.TODO(), cp, clusterWithVersion("1.21.0"))).To(Succeed())
+		})
+	})
+
+	Describe("#checkAzureAD", func() {
+		var (
+			c       *mockclient.MockClient
+			decoder = serializer.NewCodecFactory(azureapihelper.Scheme).UniversalDecoder()
+
+			secretRef = corev1.SecretReference{Name: "cloudprovider", Namespace: namespace}
+			cp        *extensionsv1alpha1.ControlPlane
+		)
+
+		BeforeEach(func() {
+			c = mockclient.NewMockClient(ctrl)
+			data, _ := json.Marshal(&apisazure.ControlPlaneConfig{
+				AzureAD: &apisazure.AzureADConfig{TenantID: "00000000-1111-2222-3333-444444444444", ValidateTenant: &[]bool{true}[0]},
+			})
+			cp = &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace},
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					DefaultSpec:    extensionsv1alpha1.DefaultSpec{},
+					SecretRef:      secretRef,
+					ProviderConfig: &runtime.RawExtension{Raw: data},
+				},
+			}
+		})
+
+		clusterWithOIDCConfig := func(oidcConfig *gardencorev1beta1.OIDCConfig) *extensionscontroller.Cluster {
+			return &extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{KubeAPIServer: &gardencorev1beta1.KubeAPIServerConfig{OIDCConfig: oidcConfig}},
+					},
+				},
+			}
+		}
+
+		It("should reject shoots which configure another OIDC provider", func() {
+			statusWriter := &conditionStatusWriter{}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cp.Name}, cp)
+			c.EXPECT().Status().Return(statusWriter)
+
+			a := &actuator{ClientContext: common.NewClientContext(c, azureapihelper.Scheme, decoder)}
+			Expect(a.checkAzureAD(context.TODO(), cp, clusterWithOIDCConfig(&gardencorev1beta1.OIDCConfig{}))).NotTo(Succeed())
+			Expect(statusWriter.conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(ConditionTypeAzureADAuthentication),
+				"Status": Equal(gardencorev1beta1.ConditionFalse),
+				"Reason": Equal(reasonAzureADConflictingOIDCConfig),
+			})))
+		})
+
+		It("should report a tenant which does not match the tenant of the credentials without failing", func() {
+			statusWriter := &conditionStatusWriter{}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}, &corev1.Secret{}).DoAndReturn(clientGet(&corev1.Secret{
+				Data: map[string][]byte{
+					azure.TenantIDKey:       []byte("aaaaaaaa-1111-2222-3333-444444444444"),
+					azure.SubscriptionIDKey: []byte("subscription"),
+					azure.ClientIDKey:       []byte("client"),
+					azure.ClientSecretKey:   []byte("secret"),
+				},
+			}))
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cp.Name}, cp)
+			c.EXPECT().Status().Return(statusWriter)
+
+			a := &actuator{ClientContext: common.NewClientContext(c, azureapihelper.Scheme, decoder)}
+			Expect(a.checkAzureAD(context.TODO(), cp, clusterWithOIDCConfig(nil))).To(Succeed())
+			Expect(statusWriter.conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(ConditionTypeAzureADAuthentication),
+				"Status": Equal(gardencorev1beta1.ConditionFalse),
+				"Reason": Equal(reasonAzureADTenantMismatch),
+			})))
+		})
+	})
+
+	Describe("#reencryptSecrets", func() {
+		var (
+			cluster      = &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+			cp           = &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+			kmsSecretKey = client.ObjectKey{Namespace: namespace, Name: azure.KMSEncryptionConfigurationName}
+			kmsSecret    = func(providers string) *corev1.Secret {
+				return &corev1.Secret{
+					Data: map[string][]byte{
+						azure.KMSEncryptionConfigurationKey: []byte(`{"resources":[{"resources":["secrets"],"providers":[` + providers + `{"identity":{}}]}]}`),
+					},
+				}
+			}
+		)
+
+		It("should do nothing if the secrets are encrypted with a single key version", func() {
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret(`{"kms":{"name":"azure-keyvault-0123456789abcdef0123456789abcdef"}},`)))
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.reencryptSecrets(context.TODO(), cp, cluster)).To(BeFalse())
+		})
+
+		It("should update the kube-apiserver and wait until it uses the current key version", func() {
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret(
+				`{"kms":{"name":"azure-keyvault-0123456789abcdef0123456789abcdef"}},{"kms":{"name":"azure-keyvault-fedcba9876543210fedcba9876543210"}},`)))
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer}, &appsv1.Deployment{})
+			c.EXPECT().Update(context.TODO(), &appsv1.Deployment{})
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.reencryptSecrets(context.TODO(), cp, cluster)).To(BeTrue())
+		})
+	})
+
+	Describe("#reencryptSecretBatch", func() {
+		var (
+			secret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+			secretList = func(continueToken string) interface{} {
+				return func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+					list.(*corev1.SecretList).Items = []corev1.Secret{secret}
+					list.(*corev1.SecretList).Continue = continueToken
+					return nil
+				}
+			}
+		)
+
+		It("should rewrite the next batch of secrets and return the continue token of the following batch", func() {
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().List(context.TODO(), &corev1.SecretList{}, client.Limit(reencryptionBatchSize), client.Continue("first")).DoAndReturn(secretList("second"))
+			c.EXPECT().Update(context.TODO(), &secret).Return(apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "foo", nil))
+
+			Expect(reencryptSecretBatch(context.TODO(), c, "first")).To(Equal("second"))
+		})
+
+		It("should restart with the first batch if the continue token is expired", func() {
+			c := mockclient.NewMockClient(ctrl)
+			gomock.InOrder(
+				c.EXPECT().List(context.TODO(), &corev1.SecretList{}, client.Limit(reencryptionBatchSize), client.Continue("expired")).Return(apierrors.NewResourceExpired("too old resource version")),
+				c.EXPECT().List(context.TODO(), &corev1.SecretList{}, client.Limit(reencryptionBatchSize), client.Continue("")).DoAndReturn(secretList("")),
+			)
+			c.EXPECT().Update(context.TODO(), &secret)
+
+			Expect(reencryptSecretBatch(context.TODO(), c, "expired")).To(BeEmpty())
+		})
+	})
+
+	Describe("#updateReencryptionContinueToken", func() {
+		It("should record the continue token in the annotations of the control plane", func() {
+			cp := &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace}}
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Patch(context.TODO(), cp, gomock.Any())
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.updateReencryptionContinueToken(context.TODO(), cp, "next")).To(Succeed())
+			Expect(cp.Annotations).To(HaveKeyWithValue(annotationReencryptionContinue, "next"))
+		})
+
+		It("should remove the continue token once all secrets have been rewritten", func() {
+			cp := &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{
+				Name:        "control-plane",
+				Namespace:   namespace,
+				Annotations: map[string]string{annotationReencryptionContinue: "next"},
+			}}
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Patch(context.TODO(), cp, gomock.Any())
+
+			a := &actuator{ClientContext: common.NewClientContext(c, nil, nil)}
+			Expect(a.updateReencryptionContinueToken(context.TODO(), cp, "")).To(Succeed())
+			Expect(cp.Annotations).NotTo(HaveKey(annotationReencryptionContinue))
+		})
+
+		It("should do nothing if the continue token is unchanged", func() {
+			cp := &extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace}}
+
+			a := &actuator{ClientContext: common.NewClientContext(mockclient.NewMockClient(ctrl), nil, nil)}
+			Expect(a.updateReencryptionContinueToken(context.TODO(), cp, "")).To(Succeed())
+		})
+	})
+
+	Describe("#updatePrivateLinkServiceAlias", func() {
+		var (
+			c       *mockclient.MockClient
+			decoder = serializer.NewCodecFactory(azureapihelper.Scheme).UniversalDecoder()
+		)
+
+		BeforeEach(func() {
+			c = mockclient.NewMockClient(ctrl)
+		})
+
+		controlPlaneWithConfig := func(cpConfig *apisazure.ControlPlaneConfig) *extensionsv1alpha1.ControlPlane {
+			data, _ := json.Marshal(cpConfig)
+			return &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace},
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					ProviderConfig: &runtime.RawExtension{Raw: data},
+				},
+			}
+		}
+
+		It("should do nothing if no private link service is configured", func() {
+			a := &actuator{ClientContext: common.NewClientContext(c, azureapihelper.Scheme, decoder)}
+
+			requeue, err := a.updatePrivateLinkServiceAlias(context.TODO(), controlPlaneWithConfig(&apisazure.ControlPlaneConfig{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+		})
+
+		It("should reject the private link service without credentials of the seed", func() {
+			cp := controlPlaneWithConfig(&apisazure.ControlPlaneConfig{
+				PrivateLinkService: &apisazure.PrivateLinkServiceConfig{Visibility: []string{"*"}},
+			})
+			statusWriter := &conditionStatusWriter{}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cp.Name}, cp)
+			c.EXPECT().Status().Return(statusWriter)
+
+			a := &actuator{
+				ClientContext:         common.NewClientContext(c, azureapihelper.Scheme, decoder),
+				kubeAPIServerExposure: &config.KubeAPIServerExposure{Private: true},
+				logger:                log.Log.WithName("test"),
+			}
+
+			_, err := a.updatePrivateLinkServiceAlias(context.TODO(), cp)
+			Expect(err).To(HaveOccurred())
+			Expect(statusWriter.conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(ConditionTypePrivateLinkService),
+				"Status": Equal(gardencorev1beta1.ConditionFalse),
+				"Reason": Equal(reasonPrivateLinkServiceMisconfigured),
+			})))
+		})
+
+		It("should fail if the credentials of the seed cannot be read", func() {
+			cp := controlPlaneWithConfig(&apisazure.ControlPlaneConfig{
+				PrivateLinkService: &apisazure.PrivateLinkServiceConfig{},
+			})
+			secretRef := corev1.SecretReference{Name: "seed-credentials", Namespace: "garden"}
+			statusWriter := &conditionStatusWriter{}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}, &corev1.Secret{}).Return(apierrors.NewNotFound(schema.GroupResource{}, secretRef.Name))
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cp.Name}, cp)
+			c.EXPECT().Status().Return(statusWriter)
+
+			a := &actuator{
+				ClientContext:         common.NewClientContext(c, azureapihelper.Scheme, decoder),
+				kubeAPIServerExposure: &config.KubeAPIServerExposure{SeedSecretRef: &secretRef, SeedResourceGroup: util.StringPtr("seed")},
+				logger:                log.Log.WithName("test"),
+			}
+
+			_, err := a.updatePrivateLinkServiceAlias(context.TODO(), cp)
+			Expect(err).To(HaveOccurred())
+			Expect(statusWriter.conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(ConditionTypePrivateLinkService),
+				"Status": Equal(gardencorev1beta1.ConditionFalse),
+				"Reason": Equal(reasonPrivateLinkServiceMisconfigured),
+			})))
+		})
+
+		It("should requeue without an error while the private link service is pending", func() {
+			cp := controlPlaneWithConfig(&apisazure.ControlPlaneConfig{
+				PrivateLinkService: &apisazure.PrivateLinkServiceConfig{},
+			})
+			secretRef := corev1.SecretReference{Name: "seed-credentials", Namespace: "garden"}
+			statusWriter := &conditionStatusWriter{}
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}, &corev1.Secret{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, secret *corev1.Secret) error {
+				secret.Data = map[string][]byte{
+					azure.SubscriptionIDKey: []byte("subscription"),
+					azure.TenantIDKey:       []byte("tenant"),
+					azure.ClientIDKey:       []byte("client"),
+					azure.ClientSecretKey:   []byte("secret"),
+				}
+				return nil
+			})
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cp.Name}, cp)
+			c.EXPECT().Status().Return(statusWriter)
+
+			defer func(f func(context.Context, *internal.ClientAuth, string, string) (*string, error)) {
+				getPrivateLinkServiceAlias = f
+			}(getPrivateLinkServiceAlias)
+			getPrivateLinkServiceAlias = func(_ context.Context, _ *internal.ClientAuth, resourceGroupName, _ string) (*string, error) {
+				Expect(resourceGroupName).To(Equal("seed"))
+				return nil, nil
+			}
+
+			a := &actuator{
+				ClientContext:         common.NewClientContext(c, azureapihelper.Scheme, decoder),
+				kubeAPIServerExposure: &config.KubeAPIServerExposure{SeedSecretRef: &secretRef, SeedResourceGroup: util.StringPtr("seed")},
+				logger:                log.Log.WithName("test"),
+			}
+
+			requeue, err := a.updatePrivateLinkServiceAlias(context.TODO(), cp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+			Expect(statusWriter.conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(ConditionTypePrivateLinkService),
+				"Status": Equal(gardencorev1beta1.ConditionProgressing),
+				"Reason": Equal(reasonPrivateLinkServicePending),
+			})))
+		})
+	})
+
+	Describe("#recreateLoadBalancerServices", func() {
+		It("should recreate the services and delete the backup", func() {
+			backup := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: loadBalancerMigrationBackupName, Namespace: namespace},
+			}
+			static := service.DeepCopy()
+			static.Spec.LoadBalancerIP = "1.2.3.4"
+			Expect(backupServices(backup, []corev1.Service{*static})).To(Succeed())
+
+			seedClient := mockclient.NewMockClient(ctrl)
+			shootClient := mockclient.NewMockClient(ctrl)
+			gomock.InOrder(
+				seedClient.EXPECT().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: loadBalancerMigrationBackupName}, &corev1.ConfigMap{}).DoAndReturn(clientGet(backup)),
+				shootClient.EXPECT().Create(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+					recreated := obj.(*corev1.Service)
+					Expect(recreated.Name).To(Equal("ingress"))
+					Expect(recreated.Namespace).To(Equal("default"))
+					Expect(recreated.Spec.ClusterIP).To(BeEmpty())
+					return nil
+				}),
+				seedClient.EXPECT().Delete(context.TODO(), backup),
+			)
+
+			releasedIPs, err := recreateLoadBalancerServices(context.TODO(), seedClient, shootClient, namespace, log.Log.WithName("test"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(releasedIPs).To(ConsistOf("default/ingress (1.2.3.4)"))
+		})
+	})
+})
+
+// conditionStatusWriter records the conditions of the control plane whose status is updated.
+type conditionStatusWriter struct {
+	conditions []gardencorev1beta1.Condition
+}
+
+func (w *conditionStatusWriter) Update(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+	w.conditions = obj.(*extensionsv1alpha1.ControlPlane).Status.Conditions
+	return nil
+}
+
+func (w *conditionStatusWriter) Patch(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+	w.conditions = obj.(*extensionsv1alpha1.ControlPlane).Status.Conditions
+	return nil
+}
+
+func deploymentGet(result *appsv1.Deployment) interface{} {
+	return func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+		*obj.(*appsv1.Deployment) = *result
+		return nil
+	}
+}
@@ -16,7 +16,9 @@ package controlplane
 
 import (
 	"context"
+	"math"
 	"path/filepath"
+	"strings"
 
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
@@ -29,13 +31,20 @@ import (
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/chart"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/gardener/gardener/pkg/utils/secrets"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apiserver/pkg/authentication/user"
 )
 
@@ -68,6 +77,32 @@ var controlPlaneSecrets = &secrets.Secrets{
 					APIServerURL: v1beta1constants.DeploymentNameKubeAPIServer,
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         azure.CSIControllerDiskName,
+					CommonName:   azure.CSIControllerDiskName,
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[v1beta1constants.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: v1beta1constants.DeploymentNameKubeAPIServer,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         azure.CSIControllerFileName,
+					CommonName:   azure.CSIControllerFileName,
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[v1beta1constants.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: v1beta1constants.DeploymentNameKubeAPIServer,
+				},
+			},
 			&secrets.ControlPlaneSecretConfig{
 				CertificateSecretConfig: &secrets.CertificateSecretConfig{
 					Name:       cloudControllerManagerServerName,
@@ -86,7 +121,7 @@ var configChart = &chart.Chart{
 	Path: filepath.Join(internal.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
 		{
-			Type: &corev1.ConfigMap{},
+			Type: &corev1.Secret{},
 			Name: azure.CloudProviderConfigName,
 		},
 		{
@@ -96,23 +131,84 @@ var configChart = &chart.Chart{
 	},
 }
 
-var ccmChart = &chart.Chart{
-	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(internal.InternalChartsPath, "cloud-controller-manager"),
-	Images: []string{azure.CloudControllerManagerImageName},
-	Objects: []*chart.Object{
-		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
-		{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
-		{Type: &corev1.ConfigMap{}, Name: "cloud-controller-manager-monitoring-config"},
+var controlPlaneChart = &chart.Chart{
+	Name: "seed-controlplane",
+	Path: filepath.Join(internal.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{
+		{
+			Name:   azure.CloudControllerManagerName,
+			Images: []string{azure.CloudControllerManagerImageName},
+			Objects: []*chart.Object{
+				{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
+				{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+				{Type: &corev1.ConfigMap{}, Name: "cloud-controller-manager-monitoring-config"},
+			},
+		},
+		{
+			Name: "csi-driver-controller",
+			Images: []string{
+				azure.CSIDriverDiskImageName,
+				azure.CSIDriverFileImageName,
+				azure.CSIProvisionerImageName,
+				azure.CSIAttacherImageName,
+				azure.CSIResizerImageName,
+				azure.CSISnapshotterImageName,
+				azure.CSILivenessProbeImageName,
+			},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: azure.CSIControllerDiskName},
+				{Type: &appsv1.Deployment{}, Name: azure.CSIControllerFileName},
+			},
+		},
+		{
+			Name: "kms",
+			Objects: []*chart.Object{
+				{Type: &corev1.Secret{}, Name: azure.KMSEncryptionConfigurationName},
+			},
+		},
 	},
 }
 
-var ccmShootChart = &chart.Chart{
-	Name: "cloud-controller-manager-shoot",
-	Path: filepath.Join(internal.InternalChartsPath, "cloud-controller-manager-shoot"),
-	Objects: []*chart.Object{
-		{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
-		{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+var controlPlaneShootChart = &chart.Chart{
+	Name: "shoot-system-components",
+	Path: filepath.Join(internal.InternalChartsPath, "shoot-system-components"),
+	SubCharts: []*chart.Chart{
+		{
+			Name: azure.CloudControllerManagerName,
+			Objects: []*chart.Object{
+				{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+			},
+		},
+		{
+			Name:   azure.CloudNodeManagerName,
+			Images: []string{azure.CloudNodeManagerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.DaemonSet{}, Name: azure.CloudNodeManagerName},
+			},
+		},
+		{
+			Name: "csi-driver-node",
+			Images: []string{
+				azure.CSIDriverDiskImageName,
+				azure.CSIDriverFileImageName,
+				azure.CSINodeDriverRegistrarImageName,
+				azure.CSILivenessProbeImageName,
+			},
+			Objects: []*chart.Object{
+				{Type: &appsv1.DaemonSet{}, Name: azure.CSINodeDiskName},
+				{Type: &appsv1.DaemonSet{}, Name: azure.CSINodeFileName},
+				{Type: &storagev1beta1.CSIDriver{}, Name: azure.CSIDiskDriverName},
+				{Type: &storagev1beta1.CSIDriver{}, Name: azure.CSIFileDriverName},
+			},
+		},
+		{
+			Name:   "csi-snapshot-controller",
+			Images: []string{azure.CSISnapshotControllerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: azure.CSISnapshotControllerName},
+			},
+		},
 	},
 }
 
@@ -161,7 +257,7 @@ func (vp *valuesProvider) GetConfigChartValues(
 	}
 
 	// Get config chart values
-	return getConfigChartValues(infraStatus, cp, cluster, auth)
+	return getConfigChartValues(cpConfig, infraStatus, cp, cluster, auth)
 }
 
 // GetControlPlaneChartValues returns the values for the control plane chart applied by the generic actuator.
@@ -180,12 +276,226 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		}
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// The cloud provider config is a secret which is not known to the generic actuator, hence, its checksum is computed here.
+	cloudProviderConfig := &corev1.Secret{}
+	if err := vp.Client().Get(ctx, kutil.Key(cp.Namespace, azure.CloudProviderConfigName), cloudProviderConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not get secret '%s/%s'", cp.Namespace, azure.CloudProviderConfigName)
+	}
+	allChecksums := map[string]string{azure.CloudProviderConfigName: util.ComputeChecksum(cloudProviderConfig.Data)}
+	for name, checksum := range checksums {
+		allChecksums[name] = checksum
+	}
+
+	// Get control plane chart values
+	values, err := getControlPlaneChartValues(cpConfig, cp, cluster, allChecksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	kms, err := vp.getKMSChartValues(ctx, cpConfig.KMS, cp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	values["kms"] = kms
+
+	return values, nil
+}
+
+// GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
+func (vp *valuesProvider) GetControlPlaneShootChartValues(
+	_ context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	_ map[string]string,
+) (map[string]interface{}, error) {
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := vp.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
+	return getControlPlaneShootChartValues(cpConfig, cluster)
+}
+
+// GetStorageClassesChartValues returns the values for the storage classes chart applied by the generic actuator.
+func (vp *valuesProvider) GetStorageClassesChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	csiEnabled, err := internal.IsCSIMigrationEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provisioner of a storage class cannot be changed, hence, clusters which already got the in-tree storage classes
+	// keep them. Their volumes are handled by the CSI drivers anyway as soon as the CSI migration is enabled.
+	useCSI := csiEnabled
+	if csiEnabled {
+		usesInTreeStorageClasses, err := vp.usesInTreeStorageClasses(ctx, cp.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		useCSI = !usesInTreeStorageClasses
+	}
+
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := vp.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
+	return getStorageClassesChartValues(cpConfig.StorageClasses, csiEnabled, useCSI), nil
+}
+
+// builtInStorageClassNames are the names of the storage classes which are deployed into every shoot by default.
+var builtInStorageClassNames = []string{"default", "managed-standard-hdd", "managed-premium-ssd", "managed-standard-ssd", "files", "files-nfs"}
+
+// getStorageClassesChartValues computes the values for the storage classes chart. Custom storage classes either extend
+// the built-in ones (replacing those with the same name) or replace all of them.
+func getStorageClassesChartValues(storageClasses *apisazure.StorageClasses, csiEnabled, useCSI bool) map[string]interface{} {
+	var (
+		disabledStorageClasses = []string{}
+		customStorageClasses   = []interface{}{}
+		defaultOverridden      bool
+	)
+
+	if storageClasses != nil {
+		if storageClasses.Mode != nil && *storageClasses.Mode == apisazure.StorageClassesModeReplace {
+			disabledStorageClasses = append(disabledStorageClasses, builtInStorageClassNames...)
+		}
+
+		for _, storageClass := range storageClasses.Classes {
+			if !utils.ValueExists(storageClass.Name, disabledStorageClasses) && utils.ValueExists(storageClass.Name, builtInStorageClassNames) {
+				disabledStorageClasses = append(disabledStorageClasses, storageClass.Name)
+			}
+
+			isDefault := storageClass.Default != nil && *storageClass.Default
+			defaultOverridden = defaultOverridden || isDefault
+
+			volumeBindingMode := string(storagev1.VolumeBindingImmediate)
+			if useCSI {
+				volumeBindingMode = string(storagev1.VolumeBindingWaitForFirstConsumer)
+			}
+			if storageClass.VolumeBindingMode != nil {
+				volumeBindingMode = *storageClass.VolumeBindingMode
+			}
+
+			reclaimPolicy := string(corev1.PersistentVolumeReclaimDelete)
+			if storageClass.ReclaimPolicy != nil {
+				reclaimPolicy = *storageClass.ReclaimPolicy
+			}
+
+			parameters := map[string]interface{}{}
+			for key, value := range storageClass.Parameters {
+				parameters[key] = value
+			}
+
+			customStorageClasses = append(customStorageClasses, map[string]interface{}{
+				"name":              storageClass.Name,
+				"sku":               storageClass.SKU,
+				"default":           isDefault,
+				"volumeBindingMode": volumeBindingMode,
+				"reclaimPolicy":     reclaimPolicy,
+				"parameters":        parameters,
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"csiEnabled":             csiEnabled,
+		"useCSI":                 useCSI,
+		"disabledStorageClasses": disabledStorageClasses,
+		"defaultOverridden":      defaultOverridden,
+		"storageClasses":         customStorageClasses,
+	}
+}
+
+// usesInTreeStorageClasses returns true if the storage classes which were already deployed into the shoot use the in-tree
+// Azure Disk volume plugin.
+func (vp *valuesProvider) usesInTreeStorageClasses(ctx context.Context, namespace string) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := vp.Client().Get(ctx, kutil.Key(namespace, genericactuator.StorageClassesChartResourceName), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "could not get secret '%s/%s'", namespace, genericactuator.StorageClassesChartResourceName)
+	}
+
+	for _, data := range secret.Data {
+		if strings.Contains(string(data), azure.AzureDiskProvisionerName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getKMSChartValues returns the values for the encryption configuration of the kube-apiserver. The key versions of the
+// existing encryption configuration are kept until the actuator has rewritten all secrets with the current key version.
+// Once secrets have been encrypted with the key vault key, the encryption cannot be disabled or moved to another key
+// because the secrets could not be decrypted anymore. The providers of the encryption configuration which is
+// maintained by Gardener are kept so that secrets which were encrypted before can still be decrypted.
+func (vp *valuesProvider) getKMSChartValues(ctx context.Context, kms *apisazure.KMSConfig, namespace string) (map[string]interface{}, error) {
+	var keyVersions []string
+	secret := &corev1.Secret{}
+	if err := vp.Client().Get(ctx, kutil.Key(namespace, azure.KMSEncryptionConfigurationName), secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "could not get secret '%s/%s'", namespace, azure.KMSEncryptionConfigurationName)
+		}
+	} else {
+		if keyVersions, err = internal.KMSKeyVersions(secret.Data[azure.KMSEncryptionConfigurationKey]); err != nil {
+			return nil, errors.Wrapf(err, "could not get key versions of secret '%s/%s'", namespace, azure.KMSEncryptionConfigurationName)
+		}
+	}
+
+	if kms == nil {
+		if len(keyVersions) > 0 {
+			return nil, errors.New("the encryption with a key vault key cannot be disabled as secrets are encrypted with it")
+		}
+		return map[string]interface{}{"enabled": false}, nil
+	}
+	if len(keyVersions) > 0 && (secret.Annotations[internal.AnnotationKMSKeyVaultName] != kms.KeyVaultName || secret.Annotations[internal.AnnotationKMSKeyName] != kms.KeyName) {
+		return nil, errors.Errorf("the key vault and the key cannot be changed as secrets are encrypted with key '%s/%s'",
+			secret.Annotations[internal.AnnotationKMSKeyVaultName], secret.Annotations[internal.AnnotationKMSKeyName])
+	}
+
+	newKeyVersions := []string{kms.KeyVersion}
+	for _, keyVersion := range keyVersions {
+		if keyVersion != kms.KeyVersion {
+			newKeyVersions = append(newKeyVersions, keyVersion)
+		}
+	}
+
+	var existingEncryptionConfiguration []byte
+	etcdEncryptionSecret := &corev1.Secret{}
+	if err := vp.Client().Get(ctx, kutil.Key(namespace, common.EtcdEncryptionSecretName), etcdEncryptionSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "could not get secret '%s/%s'", namespace, common.EtcdEncryptionSecretName)
+		}
+	} else {
+		existingEncryptionConfiguration = etcdEncryptionSecret.Data[common.EtcdEncryptionSecretFileName]
+	}
+
+	encryptionConfiguration, err := internal.KMSEncryptionConfiguration(newKeyVersions, existingEncryptionConfiguration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get encryption configuration from secret '%s/%s'", namespace, common.EtcdEncryptionSecretName)
+	}
+
+	return map[string]interface{}{
+		"enabled":                 true,
+		"keyVaultName":            kms.KeyVaultName,
+		"keyName":                 kms.KeyName,
+		"encryptionConfiguration": encryptionConfiguration,
+	}, nil
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
 func getConfigChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
 	infraStatus *apisazure.InfrastructureStatus,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
@@ -214,15 +524,25 @@ func getConfigChartValues(
 		"routeTableName":    routeTableName,
 		"securityGroupName": securityGroupName,
 		"region":            cp.Spec.Region,
-		"maxNodes":          maxNodes,
 	}
 
+	rateLimit, backoff := getCloudProviderConfigValues(cpConfig.CloudProviderConfig, maxNodes)
+	values["rateLimit"] = rateLimit
+	values["backoff"] = backoff
+
 	if infraStatus.Networks.VNet.ResourceGroup != nil {
 		values["vnetResourceGroup"] = *infraStatus.Networks.VNet.ResourceGroup
 	}
 
-	// Add AvailabilitySet config if the cluster is not zoned.
-	if !infraStatus.Zoned {
+	// Azure only supports IPv6 load balancing with standard load balancers.
+	if nodesSubnet, err := azureapihelper.FindSubnetByPurpose(infraStatus.Networks.Subnets, apisazure.PurposeNodes); err == nil && nodesSubnet.DualStack {
+		values["dualStack"] = true
+	}
+
+	// Add AvailabilitySet config if the cluster is not zoned and uses a single availability set. Basic load balancers
+	// can only balance to machines of one availability set, hence, clusters with one availability set per worker pool
+	// use standard load balancers. The same applies to clusters which are migrated to standard load balancers.
+	if !infraStatus.Zoned && !hasWorkerPoolAvailabilitySets(infraStatus) && !isLoadBalancerSKUSwitched(cpConfig, cp) {
 		nodesAvailabilitySet, err := azureapihelper.FindAvailabilitySetByPurpose(infraStatus.AvailabilitySets, apisazure.PurposeNodes)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not determine availability set for purpose 'nodes'")
@@ -233,6 +553,105 @@ func getConfigChartValues(
 	return values, nil
 }
 
+// getCloudProviderConfigValues returns the rate limit and backoff values of the cloud provider config. Settings which
+// are not configured in the given config are defaulted.
+func getCloudProviderConfigValues(config *apisazure.CloudProviderConfig, maxNodes int32) (map[string]interface{}, map[string]interface{}) {
+	var (
+		qps       = math.Max(float64(maxNodes), 10)
+		rateLimit = map[string]interface{}{
+			"enabled":     true,
+			"qps":         qps,
+			"bucket":      int32(100),
+			"qpsWrite":    qps,
+			"bucketWrite": int32(100),
+		}
+		backoff = map[string]interface{}{
+			"enabled":  true,
+			"retries":  int32(6),
+			"exponent": 1.5,
+			"duration": int32(5),
+			"jitter":   1.0,
+		}
+	)
+
+	if config == nil {
+		return rateLimit, backoff
+	}
+
+	if r := config.RateLimit; r != nil {
+		if r.Enabled != nil {
+			rateLimit["enabled"] = *r.Enabled
+		}
+		if r.QPS != nil {
+			rateLimit["qps"] = *r.QPS
+			rateLimit["qpsWrite"] = *r.QPS
+		}
+		if r.Bucket != nil {
+			rateLimit["bucket"] = *r.Bucket
+		}
+		if r.QPSWrite != nil {
+			rateLimit["qpsWrite"] = *r.QPSWrite
+		}
+		if r.BucketWrite != nil {
+			rateLimit["bucketWrite"] = *r.BucketWrite
+		}
+	}
+
+	if b := config.Backoff; b != nil {
+		if b.Enabled != nil {
+			backoff["enabled"] = *b.Enabled
+		}
+		if b.Retries != nil {
+			backoff["retries"] = *b.Retries
+		}
+		if b.Exponent != nil {
+			backoff["exponent"] = *b.Exponent
+		}
+		if b.Duration != nil {
+			backoff["duration"] = *b.Duration
+		}
+		if b.Jitter != nil {
+			backoff["jitter"] = *b.Jitter
+		}
+	}
+
+	return rateLimit, backoff
+}
+
+// hasWorkerPoolAvailabilitySets returns true if the infrastructure created one availability set per worker pool.
+func hasWorkerPoolAvailabilitySets(infraStatus *apisazure.InfrastructureStatus) bool {
+	for _, availabilitySet := range infraStatus.AvailabilitySets {
+		if availabilitySet.WorkerPool != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIControllerChartValues(cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		azure.CloudControllerManagerName: ccm,
+		"csi-driver-controller":          csi,
+	}, nil
+}
+
 // getCCMChartValues collects and returns the CCM chart values.
 func getCCMChartValues(
 	cpConfig *apisazure.ControlPlaneConfig,
@@ -250,17 +669,126 @@ func getCCMChartValues(
 			"checksum/secret-cloud-controller-manager":        checksums[cloudControllerManagerDeploymentName],
 			"checksum/secret-cloud-controller-manager-server": checksums[cloudControllerManagerServerName],
 			"checksum/secret-cloudprovider":                   checksums[v1beta1constants.SecretNameCloudProvider],
-			"checksum/configmap-cloud-provider-config":        checksums[azure.CloudProviderConfigName],
+			"checksum/secret-cloud-provider-config":           checksums[azure.CloudProviderConfigName],
 		},
 	}
 
+	featureGates := map[string]bool{}
 	if cpConfig.CloudControllerManager != nil {
-		values["featureGates"] = cpConfig.CloudControllerManager.FeatureGates
+		for feature, enabled := range cpConfig.CloudControllerManager.FeatureGates {
+			featureGates[feature] = enabled
+		}
+	}
+
+	// The cloud-controller-manager only accepts dual-stack cluster cidrs if the IPv6DualStack feature gate is enabled.
+	if isDualStackNetwork(extensionscontroller.GetPodNetwork(cluster)) {
+		dualStackSupported, err := versionutils.CompareVersions(cluster.Shoot.Spec.Kubernetes.Version, ">=", "1.16")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := featureGates["IPv6DualStack"]; dualStackSupported && !ok {
+			featureGates["IPv6DualStack"] = true
+		}
+	}
+
+	if len(featureGates) > 0 {
+		values["featureGates"] = featureGates
+	}
+
+	// The nodes are initialized by the cloud-node-manager if the kubelets run with the external cloud provider.
+	externalCloudProvider, err := internal.IsExternalCloudProviderEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+	if externalCloudProvider {
+		values["cloudNodeManager"] = true
 	}
 
 	return values, nil
 }
 
+// getCSIControllerChartValues collects and returns the CSI controller chart values.
+func getCSIControllerChartValues(
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	csiEnabled, err := internal.IsCSIMigrationEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if !csiEnabled {
+		return map[string]interface{}{"enabled": false}, nil
+	}
+
+	return map[string]interface{}{
+		"enabled":  true,
+		"replicas": extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
+		"disk": map[string]interface{}{
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-" + azure.CSIControllerDiskName: checksums[azure.CSIControllerDiskName],
+				"checksum/secret-cloud-provider-config":          checksums[azure.CloudProviderConfigName],
+			},
+		},
+		"file": map[string]interface{}{
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-" + azure.CSIControllerFileName: checksums[azure.CSIControllerFileName],
+				"checksum/secret-cloud-provider-config":          checksums[azure.CloudProviderConfigName],
+			},
+		},
+	}, nil
+}
+
+// getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
+func getControlPlaneShootChartValues(cpConfig *apisazure.ControlPlaneConfig, cluster *extensionscontroller.Cluster) (map[string]interface{}, error) {
+	csiEnabled, err := internal.IsCSIMigrationEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	externalCloudProvider, err := internal.IsExternalCloudProviderEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		azure.CloudControllerManagerName: map[string]interface{}{},
+		azure.CloudNodeManagerName: map[string]interface{}{
+			"enabled": externalCloudProvider,
+		},
+		"csi-driver-node": map[string]interface{}{
+			"enabled": csiEnabled,
+		},
+		"csi-snapshot-controller": map[string]interface{}{
+			"enabled": csiEnabled,
+			"volumeSnapshotClass": map[string]interface{}{
+				"name":       "default",
+				"parameters": getVolumeSnapshotClassParameters(cpConfig.VolumeSnapshots),
+			},
+		},
+	}, nil
+}
+
+// getVolumeSnapshotClassParameters returns the parameters of the default VolumeSnapshotClass. Snapshots are always
+// incremental as they are cheaper and faster than full snapshots.
+func getVolumeSnapshotClassParameters(config *apisazure.VolumeSnapshotConfig) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"incremental": "true",
+	}
+
+	if config != nil && config.ResourceGroup != nil {
+		parameters["resourceGroup"] = *config.ResourceGroup
+	}
+
+	return parameters
+}
+
+// isDualStackNetwork returns true if the given network consists of a comma-separated IPv4 and IPv6 CIDR.
+func isDualStackNetwork(network string) bool {
+	return strings.Contains(network, ",")
+}
+
 // getInfraNames determines the subnet, availability set, route table and security group names from the given infrastructure status.
 func getInfraNames(infraStatus *apisazure.InfrastructureStatus) (string, string, string, error) {
 	nodesSubnet, err := azureapihelper.FindSubnetByPurpose(infraStatus.Networks.Subnets, apisazure.PurposeNodes)
@@ -20,8 +20,11 @@ import (
 
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -30,17 +33,16 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
-const (
-	namespace       = "test"
-	maxNodes  int32 = 0
-)
+const namespace = "test"
 
 var _ = Describe("ValuesProvider", func() {
 	var (
@@ -431,11 +433,40 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
+		cloudProviderConfigKey    = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderConfigName}
+		kmsSecretKey              = client.ObjectKey{Namespace: namespace, Name: azure.KMSEncryptionConfigurationName}
+		cloudProviderConfigSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      azure.CloudProviderConfigName,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				azure.CloudProviderConfigMapKey: []byte("cloud: AZUREPUBLICCLOUD"),
+			},
+		}
+
 		checksums = map[string]string{
 			v1beta1constants.SecretNameCloudProvider: "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
-			azure.CloudProviderConfigName:            "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":               "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server":        "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			azure.CSIControllerDiskName:              "1b9d2ba94c0b3d1aa8e3ee16d3b4b2d1f0e2e6b8a1e4b4d5b6e5f6a9d3c2b1a0",
+			azure.CSIControllerFileName:              "c4f1e3a2b5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f70",
+		}
+
+		defaultRateLimitValues = map[string]interface{}{
+			"enabled":     true,
+			"qps":         10.0,
+			"bucket":      int32(100),
+			"qpsWrite":    10.0,
+			"bucketWrite": int32(100),
+		}
+
+		defaultBackoffValues = map[string]interface{}{
+			"enabled":  true,
+			"retries":  int32(6),
+			"exponent": 1.5,
+			"duration": int32(5),
+			"jitter":   1.0,
 		}
 
 		configNonZonedClusterChartValues = map[string]interface{}{
@@ -451,7 +482,8 @@ var _ = Describe("ValuesProvider", func() {
 			"routeTableName":      "route-table-name",
 			"securityGroupName":   "security-group-name-workers",
 			"kubernetesVersion":   "1.13.4",
-			"maxNodes":            maxNodes,
+			"rateLimit":           defaultRateLimitValues,
+			"backoff":             defaultBackoffValues,
 		}
 
 		configZonedClusterChartValues = map[string]interface{}{
@@ -466,7 +498,8 @@ var _ = Describe("ValuesProvider", func() {
 			"routeTableName":    "route-table-name",
 			"securityGroupName": "security-group-name-workers",
 			"kubernetesVersion": "1.13.4",
-			"maxNodes":          maxNodes,
+			"rateLimit":         defaultRateLimitValues,
+			"backoff":           defaultBackoffValues,
 		}
 
 		ccmChartValues = map[string]interface{}{
@@ -478,7 +511,7 @@ var _ = Describe("ValuesProvider", func() {
 				"checksum/secret-cloud-controller-manager":        "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 				"checksum/secret-cloud-controller-manager-server": "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
 				"checksum/secret-cloudprovider":                   "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
-				"checksum/configmap-cloud-provider-config":        "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
+				"checksum/secret-cloud-provider-config":           "255d14eca1577b6b7aee7be3ed47c3530b7f6ffcc139d1792fb0e53ce0740f66",
 			},
 			"featureGates": map[string]bool{
 				"CustomResourceValidation": true,
@@ -536,6 +569,99 @@ var _ = Describe("ValuesProvider", func() {
 		Expect(values).To(Equal(configZonedClusterChartValues))
 	})
 
+	It("should return config chart values with dual-stack for a dual-stack cluster", func() {
+		infraStatus := &apisazure.InfrastructureStatus{
+			ResourceGroup: apisazure.ResourceGroup{Name: "rg-abcd1234"},
+			Networks: apisazure.NetworkStatus{
+				VNet:    apisazure.VNetStatus{Name: "vnet-abcd1234"},
+				Subnets: []apisazure.Subnet{{Purpose: apisazure.PurposeNodes, Name: "subnet-abcd1234-nodes", DualStack: true}},
+			},
+			RouteTables:    []apisazure.RouteTable{{Purpose: apisazure.PurposeNodes, Name: "route-table-name"}},
+			SecurityGroups: []apisazure.SecurityGroup{{Purpose: apisazure.PurposeNodes, Name: "security-group-name-workers"}},
+			Zoned:          true,
+		}
+
+		values, err := getConfigChartValues(&apisazure.ControlPlaneConfig{}, infraStatus, cpZoned, cluster, &internal.ClientAuth{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("dualStack", true))
+	})
+
+	It("should not return the availability set name for a cluster with one availability set per worker pool", func() {
+		workerPool := "cpu-worker"
+		infraStatus := &apisazure.InfrastructureStatus{
+			ResourceGroup: apisazure.ResourceGroup{Name: "rg-abcd1234"},
+			Networks: apisazure.NetworkStatus{
+				VNet:    apisazure.VNetStatus{Name: "vnet-abcd1234"},
+				Subnets: []apisazure.Subnet{{Purpose: apisazure.PurposeNodes, Name: "subnet-abcd1234-nodes"}},
+			},
+			AvailabilitySets: []apisazure.AvailabilitySet{{Purpose: apisazure.PurposeNodes, Name: "avset-cpu-worker", WorkerPool: &workerPool}},
+			RouteTables:      []apisazure.RouteTable{{Purpose: apisazure.PurposeNodes, Name: "route-table-name"}},
+			SecurityGroups:   []apisazure.SecurityGroup{{Purpose: apisazure.PurposeNodes, Name: "security-group-name-workers"}},
+		}
+
+		values, err := getConfigChartValues(&apisazure.ControlPlaneConfig{}, infraStatus, cp, cluster, &internal.ClientAuth{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).NotTo(HaveKey("availabilitySetName"))
+	})
+
+	It("should not return the availability set name for a cluster which is migrated to standard load balancers", func() {
+		migratedCp := cp.DeepCopy()
+		migratedCp.Status.Conditions = []gardencorev1beta1.Condition{
+			{Type: ConditionTypeLoadBalancerMigration, Status: gardencorev1beta1.ConditionProgressing, Reason: "RecreatingServices"},
+		}
+		infraStatus := &apisazure.InfrastructureStatus{
+			ResourceGroup: apisazure.ResourceGroup{Name: "rg-abcd1234"},
+			Networks: apisazure.NetworkStatus{
+				VNet:    apisazure.VNetStatus{Name: "vnet-abcd1234"},
+				Subnets: []apisazure.Subnet{{Purpose: apisazure.PurposeNodes, Name: "subnet-abcd1234-nodes"}},
+			},
+			AvailabilitySets: []apisazure.AvailabilitySet{{Purpose: apisazure.PurposeNodes, Name: "availability-set-name"}},
+			RouteTables:      []apisazure.RouteTable{{Purpose: apisazure.PurposeNodes, Name: "route-table-name"}},
+			SecurityGroups:   []apisazure.SecurityGroup{{Purpose: apisazure.PurposeNodes, Name: "security-group-name-workers"}},
+		}
+
+		values, err := getConfigChartValues(&apisazure.ControlPlaneConfig{}, infraStatus, migratedCp, cluster, &internal.ClientAuth{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).NotTo(HaveKey("availabilitySetName"))
+	})
+
+	It("should return config chart values with the configured rate limit and backoff settings", func() {
+		qps, bucket, retries, enabled := 50.0, int32(500), int32(3), false
+		cpConfig := &apisazure.ControlPlaneConfig{
+			CloudProviderConfig: &apisazure.CloudProviderConfig{
+				RateLimit: &apisazure.CloudProviderRateLimit{QPS: &qps, Bucket: &bucket},
+				Backoff:   &apisazure.CloudProviderBackoff{Enabled: &enabled, Retries: &retries},
+			},
+		}
+		infraStatus := &apisazure.InfrastructureStatus{
+			ResourceGroup: apisazure.ResourceGroup{Name: "rg-abcd1234"},
+			Networks: apisazure.NetworkStatus{
+				VNet:    apisazure.VNetStatus{Name: "vnet-abcd1234"},
+				Subnets: []apisazure.Subnet{{Purpose: apisazure.PurposeNodes, Name: "subnet-abcd1234-nodes"}},
+			},
+			RouteTables:    []apisazure.RouteTable{{Purpose: apisazure.PurposeNodes, Name: "route-table-name"}},
+			SecurityGroups: []apisazure.SecurityGroup{{Purpose: apisazure.PurposeNodes, Name: "security-group-name-workers"}},
+			Zoned:          true,
+		}
+
+		values, err := getConfigChartValues(cpConfig, infraStatus, cpZoned, cluster, &internal.ClientAuth{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("rateLimit", map[string]interface{}{
+			"enabled":     true,
+			"qps":         50.0,
+			"bucket":      int32(500),
+			"qpsWrite":    50.0,
+			"bucketWrite": int32(100),
+		}))
+		Expect(values).To(HaveKeyWithValue("backoff", map[string]interface{}{
+			"enabled":  false,
+			"retries":  int32(3),
+			"exponent": 1.5,
+			"duration": int32(5),
+			"jitter":   1.0,
+		}))
+	})
+
 	Describe("#GetConfigChartValuesNoSubnet", func() {
 		It("should return error, missing subnet", func() {
 			// Create mock client
@@ -618,15 +744,329 @@ var _ = Describe("ValuesProvider", func() {
 
 	Describe("#GetControlPlaneChartValues", func() {
 		It("should return correct control plane chart values", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cloudProviderConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cloudProviderConfigSecret))
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(apierrors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+
 			// Create valuesProvider
 			vp := NewValuesProvider(logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
 
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(map[string]interface{}{
+				azure.CloudControllerManagerName: ccmChartValues,
+				"csi-driver-controller":          map[string]interface{}{"enabled": false},
+				"kms":                            map[string]interface{}{"enabled": false},
+			}))
+		})
+
+		It("should enable the CSI controller for Kubernetes versions supporting the CSI migration", func() {
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.21.0"
+
+			values, err := getCSIControllerChartValues(csiCluster, map[string]string{
+				azure.CSIControllerDiskName:   checksums[azure.CSIControllerDiskName],
+				azure.CSIControllerFileName:   checksums[azure.CSIControllerFileName],
+				azure.CloudProviderConfigName: "255d14eca1577b6b7aee7be3ed47c3530b7f6ffcc139d1792fb0e53ce0740f66",
+			}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"enabled":  true,
+				"replicas": 1,
+				"disk": map[string]interface{}{
+					"podAnnotations": map[string]interface{}{
+						"checksum/secret-csi-driver-controller-disk": checksums[azure.CSIControllerDiskName],
+						"checksum/secret-cloud-provider-config":      "255d14eca1577b6b7aee7be3ed47c3530b7f6ffcc139d1792fb0e53ce0740f66",
+					},
+				},
+				"file": map[string]interface{}{
+					"podAnnotations": map[string]interface{}{
+						"checksum/secret-csi-driver-controller-file": checksums[azure.CSIControllerFileName],
+						"checksum/secret-cloud-provider-config":      "255d14eca1577b6b7aee7be3ed47c3530b7f6ffcc139d1792fb0e53ce0740f66",
+					},
+				},
+			}))
+		})
+
+		It("should enable the IPv6DualStack feature gate for dual-stack pod networks", func() {
+			dualStackPods := "100.96.0.0/11,fd00:10:96::/48"
+			dualStackCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			dualStackCluster.Shoot.Spec.Networking.Pods = &dualStackPods
+			dualStackCluster.Shoot.Spec.Kubernetes.Version = "1.16.4"
+
+			values, err := getCCMChartValues(&apisazure.ControlPlaneConfig{}, cp, dualStackCluster, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("podNetwork", dualStackPods))
+			Expect(values).To(HaveKeyWithValue("featureGates", map[string]bool{"IPv6DualStack": true}))
+		})
+
+		It("should leave the node initialization to the cloud-node-manager for Kubernetes versions supporting the CSI migration", func() {
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.21.0"
+
+			values, err := getCCMChartValues(&apisazure.ControlPlaneConfig{}, cp, csiCluster, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("cloudNodeManager", true))
+
+			values, err = getCCMChartValues(&apisazure.ControlPlaneConfig{}, cp, cluster, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).NotTo(HaveKey("cloudNodeManager"))
+		})
+	})
+
+	Describe("#getKMSChartValues", func() {
+		var (
+			c   *mockclient.MockClient
+			vp  *valuesProvider
+			kms *apisazure.KMSConfig
+
+			etcdEncryptionSecretKey = client.ObjectKey{Namespace: namespace, Name: "etcd-encryption-secret"}
+			kmsSecret               = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      azure.KMSEncryptionConfigurationName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						internal.AnnotationKMSKeyVaultName: "my-vault",
+						internal.AnnotationKMSKeyName:      "etcd-encryption",
+					},
+				},
+				Data: map[string][]byte{
+					azure.KMSEncryptionConfigurationKey: []byte(`{"resources":[{"resources":["secrets"],"providers":[{"kms":{"name":"azure-keyvault-fedcba9876543210fedcba9876543210"}},{"identity":{}}]}]}`),
+				},
+			}
+		)
+
+		BeforeEach(func() {
+			c = mockclient.NewMockClient(ctrl)
+			vp = NewValuesProvider(logger).(*valuesProvider)
+			Expect(vp.InjectClient(c)).To(Succeed())
+
+			kms = &apisazure.KMSConfig{
+				KeyVaultName: "my-vault",
+				KeyName:      "etcd-encryption",
+				KeyVersion:   "0123456789abcdef0123456789abcdef",
+			}
+		})
+
+		It("should keep the former key versions for decryption", func() {
+			c.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret))
+			c.EXPECT().Get(context.TODO(), etcdEncryptionSecretKey, &corev1.Secret{}).Return(apierrors.NewNotFound(schema.GroupResource{}, etcdEncryptionSecretKey.Name))
+
+			values, err := vp.getKMSChartValues(context.TODO(), kms, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("keyVaultName", "my-vault"))
+			Expect(values).To(HaveKeyWithValue("keyName", "etcd-encryption"))
+			Expect(values["encryptionConfiguration"]).To(MatchYAML(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/azurekms-0123456789abcdef0123456789abcdef.socket
+      name: azure-keyvault-0123456789abcdef0123456789abcdef
+      timeout: 3s
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/azurekms-fedcba9876543210fedcba9876543210.socket
+      name: azure-keyvault-fedcba9876543210fedcba9876543210
+      timeout: 3s
+  - identity: {}
+  resources:
+  - secrets
+`))
+		})
+
+		It("should refuse to disable the encryption of encrypted secrets", func() {
+			c.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret))
+
+			_, err := vp.getKMSChartValues(context.TODO(), nil, namespace)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should refuse to change the key of encrypted secrets", func() {
+			c.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret))
+			kms.KeyName = "other-key"
+
+			_, err := vp.getKMSChartValues(context.TODO(), kms, namespace)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#GetControlPlaneShootChartValues", func() {
+		It("should return correct control plane shoot chart values", func() {
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, cluster, checksums)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				azure.CloudControllerManagerName: map[string]interface{}{},
+				azure.CloudNodeManagerName:       map[string]interface{}{"enabled": false},
+				"csi-driver-node":                map[string]interface{}{"enabled": false},
+				"csi-snapshot-controller": map[string]interface{}{
+					"enabled": false,
+					"volumeSnapshotClass": map[string]interface{}{
+						"name":       "default",
+						"parameters": map[string]interface{}{"incremental": "true"},
+					},
+				},
+			}))
+		})
+
+		It("should enable the cloud-node-manager, the CSI node plugin and the snapshot controller for Kubernetes versions supporting the CSI migration", func() {
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.21.0"
+
+			values, err := getControlPlaneShootChartValues(&apisazure.ControlPlaneConfig{}, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue(azure.CloudNodeManagerName, map[string]interface{}{"enabled": true}))
+			Expect(values).To(HaveKeyWithValue("csi-driver-node", map[string]interface{}{"enabled": true}))
+			Expect(values).To(HaveKeyWithValue("csi-snapshot-controller", HaveKeyWithValue("enabled", true)))
+		})
+
+		It("should store the snapshots in the configured resource group", func() {
+			resourceGroup := "snapshots"
+			cpConfig := &apisazure.ControlPlaneConfig{
+				VolumeSnapshots: &apisazure.VolumeSnapshotConfig{ResourceGroup: &resourceGroup},
+			}
+
+			values, err := getControlPlaneShootChartValues(cpConfig, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csi-snapshot-controller", HaveKeyWithValue("volumeSnapshotClass", map[string]interface{}{
+				"name": "default",
+				"parameters": map[string]interface{}{
+					"incremental":   "true",
+					"resourceGroup": "snapshots",
+				},
+			})))
+		})
+	})
+
+	Describe("#GetStorageClassesChartValues", func() {
+		var (
+			csiCluster        *extensionscontroller.Cluster
+			storageClassesKey = client.ObjectKey{Namespace: namespace, Name: genericactuator.StorageClassesChartResourceName}
+		)
+
+		BeforeEach(func() {
+			csiCluster = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.21.0"
+		})
+
+		It("should not use the CSI driver for Kubernetes versions not supporting the CSI migration", func() {
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csiEnabled":             false,
+				"useCSI":                 false,
+				"disabledStorageClasses": []string{},
+				"defaultOverridden":      false,
+				"storageClasses":         []interface{}{},
+			}))
+		})
+
+		It("should use the CSI driver for new clusters", func() {
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), storageClassesKey, &corev1.Secret{}).Return(apierrors.NewNotFound(schema.GroupResource{}, genericactuator.StorageClassesChartResourceName))
+
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cp, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csiEnabled", true))
+			Expect(values).To(HaveKeyWithValue("useCSI", true))
+		})
+
+		It("should keep the in-tree storage classes of existing clusters", func() {
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), storageClassesKey, &corev1.Secret{}).DoAndReturn(clientGet(&corev1.Secret{
+				Data: map[string][]byte{
+					"default-storageclass.yaml": []byte("provisioner: kubernetes.io/azure-disk"),
+				},
+			}))
+
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cp, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csiEnabled", true))
+			Expect(values).To(HaveKeyWithValue("useCSI", false))
+		})
+
+		It("should add the custom storage classes and replace the built-in ones with the same name", func() {
+			storageClasses := &apisazure.StorageClasses{
+				Classes: []apisazure.StorageClass{
+					{
+						Name:       "default",
+						SKU:        "Premium_ZRS",
+						Default:    util.BoolPtr(true),
+						Parameters: map[string]string{"cachingmode": "None"},
+					},
+					{
+						Name:              "standard-ssd-zrs",
+						SKU:               "StandardSSD_ZRS",
+						VolumeBindingMode: util.StringPtr("Immediate"),
+						ReclaimPolicy:     util.StringPtr("Retain"),
+					},
+				},
+			}
+
+			Expect(getStorageClassesChartValues(storageClasses, true, true)).To(Equal(map[string]interface{}{
+				"csiEnabled":             true,
+				"useCSI":                 true,
+				"disabledStorageClasses": []string{"default"},
+				"defaultOverridden":      true,
+				"storageClasses": []interface{}{
+					map[string]interface{}{
+						"name":              "default",
+						"sku":               "Premium_ZRS",
+						"default":           true,
+						"volumeBindingMode": "WaitForFirstConsumer",
+						"reclaimPolicy":     "Delete",
+						"parameters":        map[string]interface{}{"cachingmode": "None"},
+					},
+					map[string]interface{}{
+						"name":              "standard-ssd-zrs",
+						"sku":               "StandardSSD_ZRS",
+						"default":           false,
+						"volumeBindingMode": "Immediate",
+						"reclaimPolicy":     "Retain",
+						"parameters":        map[string]interface{}{},
+					},
+				},
+			}))
+		})
+
+		It("should disable all built-in storage classes in replace mode", func() {
+			replace := apisazure.StorageClassesModeReplace
+			storageClasses := &apisazure.StorageClasses{
+				Mode:    &replace,
+				Classes: []apisazure.StorageClass{{Name: "premium", SKU: "Premium_LRS"}},
+			}
+
+			values := getStorageClassesChartValues(storageClasses, false, false)
+			Expect(values).To(HaveKeyWithValue("disabledStorageClasses", []string{"default", "managed-standard-hdd", "managed-premium-ssd", "managed-standard-ssd", "files", "files-nfs"}))
+			Expect(values).To(HaveKeyWithValue("defaultOverridden", false))
+			Expect(values).To(HaveKeyWithValue("storageClasses", ConsistOf(HaveKeyWithValue("volumeBindingMode", "Immediate"))))
 		})
 	})
 })
@@ -20,6 +20,9 @@ import (
 	"github.com/go-logr/logr"
 
 	api "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	infrainternal "github.com/gardener/gardener-extension-provider-azure/pkg/internal/infrastructure"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
@@ -49,12 +52,48 @@ func (a *actuator) updateProviderStatus(
 	tf terraformer.Terraformer,
 	infra *extensionsv1alpha1.Infrastructure,
 	config *api.InfrastructureConfig,
+	clientAuth *internal.ClientAuth,
 ) error {
 	status, err := infrainternal.ComputeStatus(tf, config)
 	if err != nil {
 		return err
 	}
 
+	// The peering states depend on the remote side of the peerings, hence, they cannot be taken from the Terraform state.
+	if len(status.Networks.VNet.Peerings) > 0 {
+		vnetResourceGroup := status.ResourceGroup.Name
+		if status.Networks.VNet.ResourceGroup != nil {
+			vnetResourceGroup = *status.Networks.VNet.ResourceGroup
+		}
+
+		// The peerings were created successfully, hence, the states are left unknown if they cannot be determined.
+		peeringStates, err := azureclient.GetVirtualNetworkPeeringStates(ctx, clientAuth, vnetResourceGroup, status.Networks.VNet.Name)
+		if err != nil {
+			a.logger.Error(err, "could not determine the states of the vnet peerings", "infrastructure", infra.Name)
+		}
+		for i, peering := range status.Networks.VNet.Peerings {
+			status.Networks.VNet.Peerings[i].State = peeringStates[peering.Name]
+		}
+	}
+
+	// The availability sets of a cluster which is migrated to zones are removed from the status as soon as their machines
+	// are gone, they are deleted with the next reconciliation then.
+	if config.Zoned && len(status.AvailabilitySets) > 0 {
+		availabilitySets := []apiv1alpha1.AvailabilitySet{}
+		for _, availabilitySet := range status.AvailabilitySets {
+			count, err := azureclient.CountAvailabilitySetVirtualMachines(ctx, clientAuth, status.ResourceGroup.Name, availabilitySet.Name)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				availabilitySets = append(availabilitySets, availabilitySet)
+				continue
+			}
+			a.logger.Info("availability set does not contain machines anymore and will be deleted", "infrastructure", infra.Name, "availabilitySet", availabilitySet.Name)
+		}
+		status.AvailabilitySets = availabilitySets
+	}
+
 	state, err := tf.GetRawState(ctx)
 	if err != nil {
 		return err
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/internal/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
@@ -26,6 +27,7 @@ import (
 	"github.com/gardener/gardener-extensions/pkg/terraformer"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
 )
 
 // Reconcile implements infrastructure.Actuator.
@@ -40,6 +42,10 @@ func (a *actuator) Reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 		return err
 	}
 
+	if errList := validation.ValidateInfrastructureConfigAgainstSubscription(config, clientAuth.SubscriptionID); len(errList) > 0 {
+		return errors.Wrapf(errList.ToAggregate(), "invalid infrastructure config of infrastructure '%s'", infra.Name)
+	}
+
 	terraformState, err := terraformer.UnmarshalRawState(infra.Status.State)
 	if err != nil {
 		return err
@@ -66,5 +72,5 @@ func (a *actuator) Reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 		}
 	}
 
-	return a.updateProviderStatus(ctx, tf, infra, config)
+	return a.updateProviderStatus(ctx, tf, infra, config, clientAuth)
 }
@@ -22,6 +22,7 @@ import (
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	azureapi "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	azurevalidation "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
@@ -29,6 +30,7 @@ import (
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -84,10 +86,9 @@ type zoneInfo struct {
 
 func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 	var (
-		machineDeployments   = worker.MachineDeployments{}
-		machineClasses       []map[string]interface{}
-		machineImages        []apisazure.MachineImage
-		nodesAvailabilitySet *azureapi.AvailabilitySet
+		machineDeployments = worker.MachineDeployments{}
+		machineClasses     []map[string]interface{}
+		machineImages      []apisazure.MachineImage
 	)
 
 	machineClassSecretData, err := w.generateMachineClassSecretData(ctx)
@@ -105,12 +106,13 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
-	// The AvailabilitySet will be only used for non zoned Shoots.
-	if !infrastructureStatus.Zoned {
-		nodesAvailabilitySet, err = azureapihelper.FindAvailabilitySetByPurpose(infrastructureStatus.AvailabilitySets, azureapi.PurposeNodes)
-		if err != nil {
-			return err
-		}
+	infrastructureConfig, err := azureapihelper.InfrastructureConfigFromCluster(w.cluster)
+	if err != nil {
+		return err
+	}
+	controlPlaneConfig, err := azureapihelper.ControlPlaneConfigFromCluster(w.cluster)
+	if err != nil {
+		return err
 	}
 
 	for _, pool := range w.worker.Spec.Pools {
@@ -154,7 +156,37 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 			"urn": *urn,
 		}
 
-		generateMachineClassAndDeployment := func(zone *zoneInfo, availabilitySetID *string) (worker.MachineDeployment, map[string]interface{}) {
+		workerConfig := &azureapi.WorkerConfig{}
+		if pool.ProviderConfig != nil && pool.ProviderConfig.Raw != nil {
+			if _, _, err := w.Decoder().Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
+				return errors.Wrapf(err, "could not decode providerConfig of worker pool '%s'", pool.Name)
+			}
+		}
+		if infrastructureConfig != nil {
+			if errList := azurevalidation.ValidateWorkerConfig(workerConfig, infrastructureConfig, pool.Zones); len(errList) > 0 {
+				return errors.Wrapf(errList.ToAggregate(), "invalid providerConfig of worker pool '%s'", pool.Name)
+			}
+		}
+
+		var applicationSecurityGroupIDs []string
+		for _, name := range workerConfig.ApplicationSecurityGroups {
+			applicationSecurityGroup, err := azureapihelper.FindApplicationSecurityGroupByName(infrastructureStatus.ApplicationSecurityGroups, name)
+			if err != nil {
+				return err
+			}
+			applicationSecurityGroupIDs = append(applicationSecurityGroupIDs, applicationSecurityGroup.ID)
+		}
+
+		subnet := nodesSubnet
+		if workerConfig.Subnet != nil {
+			// Additional subnets are created with the cluster name as prefix.
+			subnet, err = azureapihelper.FindSubnetByName(infrastructureStatus.Networks.Subnets, fmt.Sprintf("%s-%s", w.worker.Namespace, *workerConfig.Subnet))
+			if err != nil {
+				return err
+			}
+		}
+
+		generateMachineClassAndDeployment := func(zone *zoneInfo, subnet *azureapi.Subnet, availabilitySetID, proximityPlacementGroupID *string) (worker.MachineDeployment, map[string]interface{}) {
 			var (
 				machineDeployment = worker.MachineDeployment{
 					Minimum:        pool.Minimum,
@@ -170,7 +202,7 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 					"region":        w.worker.Spec.Region,
 					"resourceGroup": infrastructureStatus.ResourceGroup.Name,
 					"vnetName":      infrastructureStatus.Networks.VNet.Name,
-					"subnetName":    nodesSubnet.Name,
+					"subnetName":    subnet.Name,
 					"tags": map[string]interface{}{
 						"Name": w.worker.Namespace,
 						fmt.Sprintf("kubernetes.io-cluster-%s", w.worker.Namespace): "1",
@@ -188,6 +220,16 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 			if infrastructureStatus.Networks.VNet.ResourceGroup != nil {
 				machineClassSpec["vnetResourceGroup"] = *infrastructureStatus.Networks.VNet.ResourceGroup
 			}
+			if len(applicationSecurityGroupIDs) > 0 {
+				machineClassSpec["applicationSecurityGroupIDs"] = applicationSecurityGroupIDs
+			}
+			if subnet.DualStack {
+				machineClassSpec["dualStack"] = true
+			}
+			if controlPlaneConfig.ACRCredentialProvider != nil {
+				// The image credential provider of the kubelets authenticates with the managed identity of the nodes.
+				machineClassSpec["identityID"] = controlPlaneConfig.ACRCredentialProvider.ManagedIdentityID
+			}
 
 			if zone != nil {
 				machineDeployment.Minimum = worker.DistributeOverZones(zone.index, pool.Minimum, zone.count)
@@ -200,6 +242,17 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 			if availabilitySetID != nil {
 				machineClassSpec["availabilitySetID"] = *availabilitySetID
 			}
+			if proximityPlacementGroupID != nil {
+				machineClassSpec["proximityPlacementGroupID"] = *proximityPlacementGroupID
+			}
+			if workerConfig.DedicatedHostGroup != nil {
+				// A specific host and the host group must not be set both, the host implies its group.
+				if workerConfig.DedicatedHostGroup.HostID != nil {
+					machineClassSpec["dedicatedHostID"] = *workerConfig.DedicatedHostGroup.HostID
+				} else {
+					machineClassSpec["dedicatedHostGroupID"] = workerConfig.DedicatedHostGroup.ID
+				}
+			}
 
 			var (
 				deploymentName = fmt.Sprintf("%s-%s", w.worker.Namespace, pool.Name)
@@ -228,22 +281,73 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 		// Availability Set
 		if !infrastructureStatus.Zoned {
-			machineDeployment, machineClassSpec := generateMachineClassAndDeployment(nil, &nodesAvailabilitySet.ID)
+			if workerConfig.DedicatedHostGroup != nil {
+				return fmt.Errorf("dedicated host group '%s' of worker pool '%s' cannot be used for a non zoned cluster", workerConfig.DedicatedHostGroup.ID, pool.Name)
+			}
+
+			// The AvailabilitySet is either shared by all worker pools or created for each worker pool.
+			nodesAvailabilitySet, err := azureapihelper.FindAvailabilitySetByWorkerPool(infrastructureStatus.AvailabilitySets, azureapi.PurposeNodes, pool.Name)
+			if err != nil {
+				return err
+			}
+
+			// The availability set is associated with the only proximity placement group of a non zoned cluster (if any),
+			// hence, all machines must be placed into this group.
+			var proximityPlacementGroupID *string
+			if workerConfig.ProximityPlacementGroup != nil {
+				proximityPlacementGroup, err := azureapihelper.FindProximityPlacementGroup(infrastructureStatus.ProximityPlacementGroups, *workerConfig.ProximityPlacementGroup, nil)
+				if err != nil {
+					return err
+				}
+				proximityPlacementGroupID = &proximityPlacementGroup.ID
+			} else if len(infrastructureStatus.ProximityPlacementGroups) > 0 {
+				proximityPlacementGroupID = &infrastructureStatus.ProximityPlacementGroups[0].ID
+			}
+
+			machineDeployment, machineClassSpec := generateMachineClassAndDeployment(nil, subnet, &nodesAvailabilitySet.ID, proximityPlacementGroupID)
 			machineDeployments = append(machineDeployments, machineDeployment)
 			machineClasses = append(machineClasses, machineClassSpec)
 			continue
 		}
 
 		// Availability Zones
+		// Without zones no machines would be generated for the pool, i.e. all its existing machines would be deleted,
+		// e.g. if a non zoned cluster is migrated to zones without adding zones to the worker pool.
+		if len(pool.Zones) == 0 {
+			return fmt.Errorf("worker pool '%s' must specify zones as the cluster is zoned", pool.Name)
+		}
 		zoneCount := len(pool.Zones)
 		for zoneIndex, zone := range pool.Zones {
+			if hostGroup := workerConfig.DedicatedHostGroup; hostGroup != nil && (hostGroup.Zone == nil || *hostGroup.Zone != zone) {
+				return fmt.Errorf("dedicated host group '%s' of worker pool '%s' is not in zone '%s' of the pool", hostGroup.ID, pool.Name, zone)
+			}
+
 			info := &zoneInfo{
 				name:  zone,
 				index: zoneIndex,
 				count: zoneCount,
 			}
 
-			machineDeployment, machineClassSpec := generateMachineClassAndDeployment(info, nil)
+			// If the infrastructure uses one subnet per zone, then the machines of a zone are placed into the subnet
+			// of their zone unless the worker pool explicitly selects a subnet.
+			zoneSubnet := subnet
+			if workerConfig.Subnet == nil && nodesSubnet.Zone != nil {
+				zoneSubnet, err = azureapihelper.FindSubnetByZone(infrastructureStatus.Networks.Subnets, zone)
+				if err != nil {
+					return err
+				}
+			}
+
+			var proximityPlacementGroupID *string
+			if workerConfig.ProximityPlacementGroup != nil {
+				proximityPlacementGroup, err := azureapihelper.FindProximityPlacementGroup(infrastructureStatus.ProximityPlacementGroups, *workerConfig.ProximityPlacementGroup, &zone)
+				if err != nil {
+					return err
+				}
+				proximityPlacementGroupID = &proximityPlacementGroup.ID
+			}
+
+			machineDeployment, machineClassSpec := generateMachineClassAndDeployment(info, zoneSubnet, nil, proximityPlacementGroupID)
 			machineDeployments = append(machineDeployments, machineDeployment)
 			machineClasses = append(machineClasses, machineClassSpec)
 		}
@@ -97,7 +97,11 @@ var _ = Describe("Machines", func() {
 				resourceGroupName string
 				vnetName          string
 				subnetName        string
+				subnetPool2       string
+				subnetNamePool2   string
 				availabilitySetID string
+				asgName           string
+				asgID             string
 				machineType       string
 				userData          []byte
 				volumeSize        int
@@ -144,7 +148,11 @@ var _ = Describe("Machines", func() {
 				resourceGroupName = "my-rg"
 				vnetName = "my-vnet"
 				subnetName = "subnet-1234"
+				subnetPool2 = "dmz"
+				subnetNamePool2 = namespace + "-" + subnetPool2
 				availabilitySetID = "av-1234"
+				asgName = "web"
+				asgID = "asg-1234"
 				machineType = "large"
 				userData = []byte("some-user-data")
 				volumeSize = 20
@@ -234,6 +242,10 @@ var _ = Describe("Machines", func() {
 											Purpose: apisazure.PurposeNodes,
 											Name:    subnetName,
 										},
+										{
+											Purpose: apisazure.PurposeNodes,
+											Name:    subnetNamePool2,
+										},
 									},
 								},
 								AvailabilitySets: []apisazure.AvailabilitySet{
@@ -242,6 +254,12 @@ var _ = Describe("Machines", func() {
 										ID:      availabilitySetID,
 									},
 								},
+								ApplicationSecurityGroups: []apisazure.ApplicationSecurityGroupStatus{
+									{
+										Name: asgName,
+										ID:   asgID,
+									},
+								},
 							}),
 						},
 						Pools: []extensionsv1alpha1.WorkerPool{
@@ -260,6 +278,15 @@ var _ = Describe("Machines", func() {
 								Volume: &extensionsv1alpha1.Volume{
 									Size: fmt.Sprintf("%dGi", volumeSize),
 								},
+								ProviderConfig: &runtime.RawExtension{
+									Raw: encode(&apiv1alpha1.WorkerConfig{
+										TypeMeta: metav1.TypeMeta{
+											APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+											Kind:       "WorkerConfig",
+										},
+										ApplicationSecurityGroups: []string{asgName},
+									}),
+								},
 							},
 							{
 								Name:           namePool2,
@@ -276,6 +303,15 @@ var _ = Describe("Machines", func() {
 								Volume: &extensionsv1alpha1.Volume{
 									Size: fmt.Sprintf("%dGi", volumeSize),
 								},
+								ProviderConfig: &runtime.RawExtension{
+									Raw: encode(&apiv1alpha1.WorkerConfig{
+										TypeMeta: metav1.TypeMeta{
+											APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+											Kind:       "WorkerConfig",
+										},
+										Subnet: &subnetPool2,
+									}),
+								},
 							},
 						},
 					},
@@ -335,6 +371,9 @@ var _ = Describe("Machines", func() {
 						machineClassWithHashPool2 = fmt.Sprintf("%s-%s", machineClassNamePool2, workerPoolHash2)
 					)
 
+					machineClassPool1["applicationSecurityGroupIDs"] = []string{asgID}
+					machineClassPool2["subnetName"] = subnetNamePool2
+
 					addNameAndSecretsToMachineClass(machineClassPool1, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID, machineClassWithHashPool1)
 					addNameAndSecretsToMachineClass(machineClassPool2, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID, machineClassWithHashPool2)
 
@@ -412,6 +451,300 @@ var _ = Describe("Machines", func() {
 				})
 			})
 
+			It("should place the machines of a zone into the subnet of the zone", func() {
+				var (
+					zone1, zone2             = "1", "2"
+					subnetZone1, subnetZone2 = "subnet-z1", "subnet-z2"
+					values                   map[string]interface{}
+				)
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetZone1, Zone: &zone1},
+								{Purpose: apisazure.PurposeNodes, Name: subnetZone2, Zone: &zone2},
+							},
+						},
+						Zoned: true,
+					}),
+				}
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].ProviderConfig = nil
+				w.Spec.Pools[0].Zones = []string{zone1, zone2}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).To(HaveLen(2))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("zone", zone1))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("subnetName", subnetZone1))
+				Expect(machineClasses[1]).To(HaveKeyWithValue("zone", zone2))
+				Expect(machineClasses[1]).To(HaveKeyWithValue("subnetName", subnetZone2))
+			})
+
+			It("should configure an ipv6 ip configuration for machines in a dual-stack subnet", func() {
+				var values map[string]interface{}
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName, DualStack: true},
+							},
+						},
+						Zoned: true,
+					}),
+				}
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].ProviderConfig = nil
+				w.Spec.Pools[0].Zones = []string{"1"}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).To(HaveLen(1))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("dualStack", true))
+			})
+
+			It("should place the machines of a zone into the proximity placement group of the zone", func() {
+				var (
+					zone1, zone2 = "1", "2"
+					values       map[string]interface{}
+				)
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName},
+							},
+						},
+						ProximityPlacementGroups: []apisazure.ProximityPlacementGroupStatus{
+							{Name: "trading", ID: "ppg-z1", Purpose: apisazure.PurposeNodes, Zone: &zone1},
+							{Name: "trading", ID: "ppg-z2", Purpose: apisazure.PurposeNodes, Zone: &zone2},
+						},
+						Zoned: true,
+					}),
+				}
+				proximityPlacementGroup := "trading"
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						ProximityPlacementGroup: &proximityPlacementGroup,
+					}),
+				}
+				w.Spec.Pools[0].Zones = []string{zone1, zone2}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).To(HaveLen(2))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("proximityPlacementGroupID", "ppg-z1"))
+				Expect(machineClasses[1]).To(HaveKeyWithValue("proximityPlacementGroupID", "ppg-z2"))
+			})
+
+			It("should place the machines on the dedicated host group", func() {
+				var (
+					zone        = "1"
+					hostGroupID = "/subscriptions/sub/resourceGroups/hosts/providers/Microsoft.Compute/hostGroups/licensed"
+					values      map[string]interface{}
+				)
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName},
+							},
+						},
+						Zoned: true,
+					}),
+				}
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						DedicatedHostGroup: &apiv1alpha1.DedicatedHostGroup{ID: hostGroupID, Zone: &zone},
+					}),
+				}
+				w.Spec.Pools[0].Zones = []string{zone}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).To(HaveLen(1))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("dedicatedHostGroupID", hostGroupID))
+				Expect(machineClasses[0]).NotTo(HaveKey("dedicatedHostID"))
+			})
+
+			It("should assign the managed identity of the image credential provider to the machines", func() {
+				var (
+					identityID = "/subscriptions/sub/resourceGroups/identities/providers/Microsoft.ManagedIdentity/userAssignedIdentities/nodes"
+					values     map[string]interface{}
+				)
+
+				cluster.Shoot.Spec.Provider.ControlPlaneConfig = &gardencorev1beta1.ProviderConfig{
+					RawExtension: runtime.RawExtension{
+						Raw: encode(&apiv1alpha1.ControlPlaneConfig{
+							TypeMeta: metav1.TypeMeta{
+								APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+								Kind:       "ControlPlaneConfig",
+							},
+							ACRCredentialProvider: &apiv1alpha1.ACRCredentialProviderConfig{
+								Registries:              []string{"myregistry.azurecr.io"},
+								ManagedIdentityClientID: "00000000-1111-2222-3333-444444444444",
+								ManagedIdentityID:       identityID,
+							},
+						}),
+					},
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).NotTo(BeEmpty())
+				for _, machineClass := range machineClasses {
+					Expect(machineClass).To(HaveKeyWithValue("identityID", identityID))
+				}
+			})
+
+			It("should place the machines of each worker pool into the availability set of the pool", func() {
+				var values map[string]interface{}
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName},
+							},
+						},
+						AvailabilitySets: []apisazure.AvailabilitySet{
+							{Purpose: apisazure.PurposeNodes, ID: "av-pool-2", WorkerPool: &namePool2},
+							{Purpose: apisazure.PurposeNodes, ID: "av-pool-1", WorkerPool: &namePool1},
+						},
+					}),
+				}
+				w.Spec.Pools[0].ProviderConfig = nil
+				w.Spec.Pools[1].ProviderConfig = nil
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				chartApplier.
+					EXPECT().
+					ApplyChart(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _, _, _ string, defaultValues, _ map[string]interface{}) error {
+						values = defaultValues
+						return nil
+					})
+
+				err := workerDelegate.DeployMachineClasses(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				machineClasses := values["machineClasses"].([]map[string]interface{})
+				Expect(machineClasses).To(HaveLen(2))
+				Expect(machineClasses[0]).To(HaveKeyWithValue("availabilitySetID", "av-pool-1"))
+				Expect(machineClasses[1]).To(HaveKeyWithValue("availabilitySetID", "av-pool-2"))
+			})
+
 			It("should fail because the secret cannot be read", func() {
 				c.EXPECT().
 					Get(context.TODO(), gomock.Any(), gomock.AssignableToTypeOf(&corev1.Secret{})).
@@ -482,6 +815,162 @@ var _ = Describe("Machines", func() {
 				Expect(result).To(BeNil())
 			})
 
+			It("should fail because the application security group cannot be found", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						ApplicationSecurityGroups: []string{"unknown"},
+					}),
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
+			It("should fail because the subnet cannot be found", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				unknownSubnet := "unknown"
+				w.Spec.Pools[1].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						Subnet: &unknownSubnet,
+					}),
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
+			It("should fail because the proximity placement group cannot be found", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				unknownProximityPlacementGroup := "unknown"
+				w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						ProximityPlacementGroup: &unknownProximityPlacementGroup,
+					}),
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
+			It("should fail because the zone of the dedicated host group does not match the zone of the pool", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				zone := "3"
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName},
+							},
+						},
+						Zoned: true,
+					}),
+				}
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].Zones = []string{"1"}
+				w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{
+					Raw: encode(&apiv1alpha1.WorkerConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerConfig",
+						},
+						DedicatedHostGroup: &apiv1alpha1.DedicatedHostGroup{
+							ID:   "/subscriptions/sub/resourceGroups/hosts/providers/Microsoft.Compute/hostGroups/licensed",
+							Zone: &zone,
+						},
+					}),
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(MatchError(ContainSubstring("is not in zone")))
+				Expect(result).To(BeNil())
+			})
+
+			It("should fail because the worker config references an application security group not in the infrastructure config", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				cluster.Shoot.Spec.Provider.InfrastructureConfig = &gardencorev1beta1.ProviderConfig{
+					RawExtension: runtime.RawExtension{
+						Raw: encode(&apiv1alpha1.InfrastructureConfig{
+							TypeMeta: metav1.TypeMeta{
+								APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+								Kind:       "InfrastructureConfig",
+							},
+						}),
+					},
+				}
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("applicationSecurityGroups[0]"))
+				Expect(result).To(BeNil())
+			})
+
+			It("should fail because a worker pool of a zoned cluster does not specify zones", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+
+				w.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureStatus{
+						ResourceGroup: apisazure.ResourceGroup{
+							Name: resourceGroupName,
+						},
+						Networks: apisazure.NetworkStatus{
+							VNet: apisazure.VNetStatus{
+								Name: vnetName,
+							},
+							Subnets: []apisazure.Subnet{
+								{Purpose: apisazure.PurposeNodes, Name: subnetName},
+							},
+						},
+						Zoned: true,
+					}),
+				}
+				w.Spec.Pools = w.Spec.Pools[:1]
+				w.Spec.Pools[0].ProviderConfig = nil
+				w.Spec.Pools[0].Zones = nil
+
+				workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
 			It("should fail because the machine image information cannot be found", func() {
 				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
 
@@ -0,0 +1,39 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// HasOIDCConfig returns true if the given shoot configures an OIDC provider for its kube-apiserver. The Azure Active
+// Directory authentication is not applied to such shoots as it would silently override their OIDC provider.
+func HasOIDCConfig(shoot *gardencorev1beta1.Shoot) bool {
+	kubeAPIServer := shoot.Spec.Kubernetes.KubeAPIServer
+	return kubeAPIServer != nil && kubeAPIServer.OIDCConfig != nil
+}
+
+// IsAzureADTenantValid returns false if the tenant of the given Azure Active Directory configuration must be the tenant
+// of the given cloud provider credentials but is not.
+func IsAzureADTenantValid(azureAD *apisazure.AzureADConfig, ca *ClientAuth) bool {
+	if azureAD.ValidateTenant == nil || !*azureAD.ValidateTenant {
+		return true
+	}
+	return strings.EqualFold(azureAD.TenantID, ca.TenantID)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+)
+
+// IsExternalCloudProviderEnabled returns true if the kubelets of Shoot clusters with the given Kubernetes version run
+// with the external cloud provider, i.e., the nodes are initialized by the cloud-node-manager instead of the in-tree
+// cloud provider of the kubelets.
+func IsExternalCloudProviderEnabled(kubernetesVersion string) (bool, error) {
+	return versionutils.CompareVersions(kubernetesVersion, ">=", "1.21")
+}
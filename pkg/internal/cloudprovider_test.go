@@ -0,0 +1,39 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloudProvider", func() {
+	table.DescribeTable("#IsExternalCloudProviderEnabled",
+		func(version string, expected bool) {
+			enabled, err := IsExternalCloudProviderEnabled(version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enabled).To(Equal(expected))
+		},
+		table.Entry("should return false for 1.20", "1.20.4", false),
+		table.Entry("should return true for 1.21", "1.21.0", true),
+		table.Entry("should return true for 1.22", "1.22.1", true),
+	)
+
+	It("should return an error for an invalid version", func() {
+		_, err := IsExternalCloudProviderEnabled("foo")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationCSIMigrationComplete is the annotation of the Cluster resource which marks that all kubelets of the shoot
+// use the CSI drivers. The control plane components are switched to the CSI drivers only afterwards because kubelets
+// of older Kubernetes versions still use the in-tree volume plugins.
+const AnnotationCSIMigrationComplete = "azure.provider.extensions.gardener.cloud/csi-migration-complete"
+
+// IsCSIMigrationEnabled returns true if the Azure Disk and Azure File CSI drivers are deployed and the in-tree volume
+// plugins are migrated to them for Shoot clusters with the given Kubernetes version.
+func IsCSIMigrationEnabled(kubernetesVersion string) (bool, error) {
+	return versionutils.CompareVersions(kubernetesVersion, ">=", "1.21")
+}
+
+// IsCSIMigrationComplete returns true if the CSI migration is enabled for the given Kubernetes version and the Cluster
+// resource in the given namespace is marked as migrated.
+func IsCSIMigrationComplete(ctx context.Context, c client.Client, namespace, kubernetesVersion string) (bool, error) {
+	csiEnabled, err := IsCSIMigrationEnabled(kubernetesVersion)
+	if err != nil || !csiEnabled {
+		return false, err
+	}
+
+	cluster := &extensionsv1alpha1.Cluster{}
+	if err := c.Get(ctx, kutil.Key(namespace), cluster); err != nil {
+		return false, err
+	}
+	return metav1.HasAnnotation(cluster.ObjectMeta, AnnotationCSIMigrationComplete), nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CSI", func() {
+	table.DescribeTable("#IsCSIMigrationEnabled",
+		func(version string, expected bool) {
+			enabled, err := IsCSIMigrationEnabled(version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enabled).To(Equal(expected))
+		},
+		table.Entry("should return false for 1.20", "1.20.4", false),
+		table.Entry("should return true for 1.21", "1.21.0", true),
+		table.Entry("should return true for 1.22", "1.22.1", true),
+	)
+
+	It("should return an error for an invalid version", func() {
+		_, err := IsCSIMigrationEnabled("foo")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("#IsCSIMigrationComplete", func() {
+		var (
+			ctrl *gomock.Controller
+			c    *mockclient.MockClient
+			ctx  = context.TODO()
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			c = mockclient.NewMockClient(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		expectGetCluster := func(annotations map[string]string) {
+			c.EXPECT().Get(ctx, kutil.Key("shoot--foo--bar"), gomock.AssignableToTypeOf(&extensionsv1alpha1.Cluster{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, actual *extensionsv1alpha1.Cluster) error {
+					actual.Annotations = annotations
+					return nil
+				})
+		}
+
+		It("should return false without reading the cluster for 1.20", func() {
+			complete, err := IsCSIMigrationComplete(ctx, c, "shoot--foo--bar", "1.20.4")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(complete).To(BeFalse())
+		})
+
+		It("should return false if the cluster is not marked as migrated", func() {
+			expectGetCluster(nil)
+
+			complete, err := IsCSIMigrationComplete(ctx, c, "shoot--foo--bar", "1.21.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(complete).To(BeFalse())
+		})
+
+		It("should return true if the cluster is marked as migrated", func() {
+			expectGetCluster(map[string]string{AnnotationCSIMigrationComplete: "true"})
+
+			complete, err := IsCSIMigrationComplete(ctx, c, "shoot--foo--bar", "1.21.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(complete).To(BeTrue())
+		})
+	})
+})
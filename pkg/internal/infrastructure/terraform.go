@@ -15,7 +15,9 @@
 package infrastructure
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	api "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
@@ -27,6 +29,7 @@ import (
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/chartrenderer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -45,10 +48,31 @@ const (
 	TerraformerOutputKeyAvailabilitySetID = "availabilitySetID"
 	// TerraformerOutputKeyAvailabilitySetName is the key for the availabilitySetName output
 	TerraformerOutputKeyAvailabilitySetName = "availabilitySetName"
+	// TerraformerOutputKeyAvailabilitySetWorkerPools is the key for the output of the worker pools which got an own
+	// availability set
+	TerraformerOutputKeyAvailabilitySetWorkerPools = "availabilitySetWorkerPools"
+	// TerraformerOutputKeyAvailabilitySetIDPrefix is the prefix of the keys for the worker pool availability set id outputs
+	TerraformerOutputKeyAvailabilitySetIDPrefix = "availabilitySetID-"
+	// TerraformerOutputKeyAvailabilitySetNamePrefix is the prefix of the keys for the worker pool availability set name outputs
+	TerraformerOutputKeyAvailabilitySetNamePrefix = "availabilitySetName-"
 	// TerraformerOutputKeyRouteTableName is the key for the routeTableName output
 	TerraformerOutputKeyRouteTableName = "routeTableName"
 	// TerraformerOutputKeySecurityGroupName is the key for the securityGroupName output
 	TerraformerOutputKeySecurityGroupName = "securityGroupName"
+	// TerraformerOutputKeyApplicationSecurityGroupIDPrefix is the prefix of the keys for the application security group id outputs
+	TerraformerOutputKeyApplicationSecurityGroupIDPrefix = "applicationSecurityGroupID-"
+	// TerraformerOutputKeyVNetPeeringIDPrefix is the prefix of the keys for the vnet peering id outputs
+	TerraformerOutputKeyVNetPeeringIDPrefix = "vnetPeeringID-"
+	// TerraformerOutputKeyZoneSubnetNamePrefix is the prefix of the keys for the zone subnet name outputs
+	TerraformerOutputKeyZoneSubnetNamePrefix = "zoneSubnetName-"
+	// TerraformerOutputKeySubnetNamePrefix is the prefix of the keys for the additional subnet name outputs
+	TerraformerOutputKeySubnetNamePrefix = "subnetName-"
+	// TerraformerOutputKeySecurityGroupNamePrefix is the prefix of the keys for the dedicated subnet security group name outputs
+	TerraformerOutputKeySecurityGroupNamePrefix = "securityGroupName-"
+	// TerraformerOutputKeyProximityPlacementGroupIDPrefix is the prefix of the keys for the proximity placement group id outputs
+	TerraformerOutputKeyProximityPlacementGroupIDPrefix = "proximityPlacementGroupID-"
+	// TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix is the prefix of the keys for the private dns zone link id outputs
+	TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix = "privateDNSZoneLinkID-"
 )
 
 var (
@@ -81,7 +105,8 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 		vnetConfig = map[string]interface{}{
 			"name": infra.Namespace,
 		}
-		outputKeys = map[string]interface{}{
+		availabilitySetConfig = map[string]interface{}{}
+		outputKeys            = map[string]interface{}{
 			"resourceGroupName": TerraformerOutputKeyResourceGroupName,
 			"vnetName":          TerraformerOutputKeyVNetName,
 			"subnetName":        TerraformerOutputKeySubnetName,
@@ -110,12 +135,57 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 		vnetConfig["cidr"] = config.Networks.Workers
 	}
 
-	// If the cluster is zoned, then we don't need to create an AvailabilitySet.
+	if createVNet && config.Networks.IPv6Workers != nil {
+		// Use the ipv6 worker cidr as default for the ipv6 address space of the vNet.
+		vnetConfig["ipv6CIDR"] = *config.Networks.IPv6Workers
+		if config.Networks.VNet.IPv6CIDR != nil {
+			vnetConfig["ipv6CIDR"] = *config.Networks.VNet.IPv6CIDR
+		}
+	}
+
+	if createVNet && len(config.Networks.VNet.DNSServers) > 0 {
+		vnetConfig["dnsServers"] = config.Networks.VNet.DNSServers
+	}
+
+	// If the cluster is zoned, then we don't need to create an AvailabilitySet. However, the availability sets of a
+	// cluster which is migrated to zones are kept until their machines are gone.
+	var (
+		createSharedAvailabilitySet      bool
+		createWorkerPoolAvailabilitySets bool
+		workerPoolAvailabilitySets       []string
+	)
 	if !config.Zoned {
+		if config.AvailabilitySetPerWorkerPool {
+			workerPools, err := availabilitySetWorkerPools(infra, cluster)
+			if err != nil {
+				return nil, err
+			}
+			createWorkerPoolAvailabilitySets, workerPoolAvailabilitySets = true, workerPools
+		} else {
+			createSharedAvailabilitySet = true
+		}
+	} else {
+		shared, workerPools, err := migratingAvailabilitySets(infra)
+		if err != nil {
+			return nil, err
+		}
+		createSharedAvailabilitySet = shared
+		createWorkerPoolAvailabilitySets, workerPoolAvailabilitySets = len(workerPools) > 0, workerPools
+	}
+
+	if createSharedAvailabilitySet {
 		createAvailabilitySet = true
 		outputKeys["availabilitySetID"] = TerraformerOutputKeyAvailabilitySetID
 		outputKeys["availabilitySetName"] = TerraformerOutputKeyAvailabilitySetName
+	}
+	if createWorkerPoolAvailabilitySets {
+		availabilitySetConfig["workerPools"] = workerPoolAvailabilitySets
+		outputKeys["availabilitySetWorkerPools"] = TerraformerOutputKeyAvailabilitySetWorkerPools
+		outputKeys["availabilitySetIDPrefix"] = TerraformerOutputKeyAvailabilitySetIDPrefix
+		outputKeys["availabilitySetNamePrefix"] = TerraformerOutputKeyAvailabilitySetNamePrefix
+	}
 
+	if createSharedAvailabilitySet || createWorkerPoolAvailabilitySets {
 		cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
 		if err != nil {
 			return nil, err
@@ -132,9 +202,36 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 			return nil, err
 		}
 		azure["countFaultDomains"] = countFaultDomains
+
+		// The machines of an availability set can only be placed into the proximity placement group the availability
+		// set is associated with.
+		if !config.Zoned && len(config.ProximityPlacementGroups) > 0 {
+			availabilitySetConfig["proximityPlacementGroup"] = proximityPlacementGroupKey(config.ProximityPlacementGroups[0])
+		}
 	}
 
-	return map[string]interface{}{
+	networks := map[string]interface{}{
+		"worker": config.Networks.Workers,
+	}
+	if config.Networks.IPv6Workers != nil {
+		networks["workerIPv6"] = *config.Networks.IPv6Workers
+	}
+	if len(config.Networks.Zones) > 0 {
+		var zones []map[string]interface{}
+		for _, zone := range config.Networks.Zones {
+			zones = append(zones, map[string]interface{}{
+				"name": zone.Name,
+				"cidr": zone.CIDR,
+			})
+		}
+		networks = map[string]interface{}{
+			"zones": zones,
+		}
+		delete(outputKeys, "subnetName")
+		outputKeys["zoneSubnetNamePrefix"] = TerraformerOutputKeyZoneSubnetNamePrefix
+	}
+
+	values := map[string]interface{}{
 		"azure": azure,
 		"create": map[string]interface{}{
 			"resourceGroup":   createResourceGroup,
@@ -149,11 +246,217 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 			},
 		},
 		"clusterName": infra.Namespace,
-		"networks": map[string]interface{}{
-			"worker": config.Networks.Workers,
-		},
-		"outputKeys": outputKeys,
-	}, nil
+		"networks":    networks,
+		"outputKeys":  outputKeys,
+	}
+
+	if len(config.Networks.ApplicationSecurityGroups) > 0 {
+		var applicationSecurityGroups []map[string]interface{}
+		for _, applicationSecurityGroup := range config.Networks.ApplicationSecurityGroups {
+			applicationSecurityGroups = append(applicationSecurityGroups, map[string]interface{}{
+				"name": applicationSecurityGroup.Name,
+			})
+		}
+		values["applicationSecurityGroups"] = applicationSecurityGroups
+		outputKeys["applicationSecurityGroupIDPrefix"] = TerraformerOutputKeyApplicationSecurityGroupIDPrefix
+	}
+
+	if len(config.Networks.SecurityRules) > 0 {
+		values["securityRules"] = computeSecurityRules(config.Networks.SecurityRules)
+	}
+
+	if len(config.Networks.VNet.Peerings) > 0 {
+		var peerings []map[string]interface{}
+		for _, peering := range config.Networks.VNet.Peerings {
+			peerings = append(peerings, map[string]interface{}{
+				"name":                  peering.Name,
+				"remoteVNetID":          peering.RemoteVNetID,
+				"allowForwardedTraffic": peering.AllowForwardedTraffic,
+				"useRemoteGateways":     peering.UseRemoteGateways,
+			})
+		}
+		values["vnetPeerings"] = peerings
+		outputKeys["vnetPeeringIDPrefix"] = TerraformerOutputKeyVNetPeeringIDPrefix
+	}
+
+	if len(availabilitySetConfig) > 0 {
+		values["availabilitySet"] = availabilitySetConfig
+	}
+
+	if len(config.ProximityPlacementGroups) > 0 {
+		var proximityPlacementGroups []map[string]interface{}
+		for _, proximityPlacementGroup := range config.ProximityPlacementGroups {
+			proximityPlacementGroups = append(proximityPlacementGroups, map[string]interface{}{
+				"key": proximityPlacementGroupKey(proximityPlacementGroup),
+			})
+		}
+		values["proximityPlacementGroups"] = proximityPlacementGroups
+		outputKeys["proximityPlacementGroupIDPrefix"] = TerraformerOutputKeyProximityPlacementGroupIDPrefix
+	}
+
+	if len(config.Networks.VNet.PrivateDNSZoneIDs) > 0 {
+		var privateDNSZoneLinks []map[string]interface{}
+		for _, privateDNSZoneID := range config.Networks.VNet.PrivateDNSZoneIDs {
+			_, resourceGroup, zoneName := parsePrivateDNSZoneID(privateDNSZoneID)
+			privateDNSZoneLinks = append(privateDNSZoneLinks, map[string]interface{}{
+				"key":           privateDNSZoneLinkKey(privateDNSZoneID),
+				"resourceGroup": resourceGroup,
+				"zoneName":      zoneName,
+			})
+		}
+		values["privateDNSZoneLinks"] = privateDNSZoneLinks
+		outputKeys["privateDNSZoneLinkIDPrefix"] = TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix
+	}
+
+	if len(config.Networks.Routes) > 0 {
+		var routes []map[string]interface{}
+		for _, route := range config.Networks.Routes {
+			routeValues := map[string]interface{}{
+				"name":          route.Name,
+				"addressPrefix": route.AddressPrefix,
+				"nextHopType":   string(route.NextHopType),
+			}
+			if route.NextHopIPAddress != nil {
+				routeValues["nextHopIPAddress"] = *route.NextHopIPAddress
+			}
+			routes = append(routes, routeValues)
+		}
+		values["routes"] = routes
+	}
+
+	if len(config.Networks.Subnets) > 0 {
+		var subnets []map[string]interface{}
+		for _, subnet := range config.Networks.Subnets {
+			subnetValues := map[string]interface{}{
+				"name":             subnet.Name,
+				"cidr":             subnet.CIDR,
+				"serviceEndpoints": subnet.ServiceEndpoints,
+			}
+			if subnet.SecurityGroup != nil {
+				subnetValues["securityGroup"] = *subnet.SecurityGroup
+			}
+			subnets = append(subnets, subnetValues)
+		}
+		values["subnets"] = subnets
+		outputKeys["subnetNamePrefix"] = TerraformerOutputKeySubnetNamePrefix
+		outputKeys["securityGroupNamePrefix"] = TerraformerOutputKeySecurityGroupNamePrefix
+	}
+
+	return values, nil
+}
+
+// migratingAvailabilitySets returns whether the shared availability set and the names of the worker pools whose
+// availability sets have to be kept while the cluster is migrated to zones. These are the availability sets of the
+// last InfrastructureStatus, they are removed from the status as soon as they do not contain any machines anymore.
+func migratingAvailabilitySets(infra *extensionsv1alpha1.Infrastructure) (bool, []string, error) {
+	status, err := helper.InfrastructureStatusFromInfrastructure(infra)
+	if err != nil || status == nil {
+		return false, nil, err
+	}
+
+	var (
+		shared      bool
+		workerPools []string
+	)
+	for _, availabilitySet := range status.AvailabilitySets {
+		if availabilitySet.WorkerPool == nil {
+			shared = true
+			continue
+		}
+		workerPools = append(workerPools, *availabilitySet.WorkerPool)
+	}
+	return shared, workerPools, nil
+}
+
+// availabilitySetWorkerPools returns the sorted names of the worker pools which get an own availability set. These are
+// the worker pools of the cluster and the worker pools which already got an availability set earlier. The availability
+// sets of removed worker pools are kept as they cannot be deleted before their machines, and the infrastructure is
+// reconciled before the machines of removed worker pools are deleted.
+func availabilitySetWorkerPools(infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) ([]string, error) {
+	workerPools := sets.NewString()
+	if cluster != nil && cluster.Shoot != nil {
+		for _, worker := range cluster.Shoot.Spec.Provider.Workers {
+			workerPools.Insert(worker.Name)
+		}
+	}
+
+	status, err := helper.InfrastructureStatusFromInfrastructure(infra)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		for _, availabilitySet := range status.AvailabilitySets {
+			if availabilitySet.WorkerPool != nil {
+				workerPools.Insert(*availabilitySet.WorkerPool)
+			}
+		}
+	}
+
+	return workerPools.List(), nil
+}
+
+// proximityPlacementGroupKey returns the key of the given proximity placement group which is used for the Terraform
+// resource and output names. Groups of zoned clusters exist once per zone, hence, the zone is part of the key.
+func proximityPlacementGroupKey(proximityPlacementGroup api.ProximityPlacementGroup) string {
+	if proximityPlacementGroup.Zone != nil {
+		return fmt.Sprintf("%s-z%s", proximityPlacementGroup.Name, *proximityPlacementGroup.Zone)
+	}
+	return proximityPlacementGroup.Name
+}
+
+// parsePrivateDNSZoneID returns the subscription id, resource group and name of the given (validated) private DNS zone id.
+func parsePrivateDNSZoneID(privateDNSZoneID string) (string, string, string) {
+	parts := strings.Split(strings.TrimPrefix(privateDNSZoneID, "/"), "/")
+	return parts[1], parts[3], parts[len(parts)-1]
+}
+
+// privateDNSZoneLinkKey returns the key of the virtual network link of the given private DNS zone which is used for the
+// Terraform resource and output names. Terraform does not allow dots in names, hence, they are replaced by underscores.
+func privateDNSZoneLinkKey(privateDNSZoneID string) string {
+	_, _, zoneName := parsePrivateDNSZoneID(privateDNSZoneID)
+	return strings.Replace(strings.ToLower(zoneName), ".", "_", -1)
+}
+
+// computeSecurityRules computes the chart values for the given security rules. Ranges and prefixes which are
+// not specified match everything.
+func computeSecurityRules(securityRules []api.SecurityRule) []map[string]interface{} {
+	var (
+		out      []map[string]interface{}
+		anyOrVal = func(val *string) string {
+			if val == nil {
+				return "*"
+			}
+			return *val
+		}
+	)
+
+	for _, rule := range securityRules {
+		values := map[string]interface{}{
+			"name":                 rule.Name,
+			"priority":             rule.Priority,
+			"direction":            string(rule.Direction),
+			"access":               string(rule.Access),
+			"protocol":             string(rule.Protocol),
+			"sourcePortRange":      anyOrVal(rule.SourcePortRange),
+			"destinationPortRange": anyOrVal(rule.DestinationPortRange),
+		}
+
+		if len(rule.SourceApplicationSecurityGroups) > 0 {
+			values["sourceApplicationSecurityGroups"] = rule.SourceApplicationSecurityGroups
+		} else {
+			values["sourceAddressPrefix"] = anyOrVal(rule.SourceAddressPrefix)
+		}
+
+		if len(rule.DestinationApplicationSecurityGroups) > 0 {
+			values["destinationApplicationSecurityGroups"] = rule.DestinationApplicationSecurityGroups
+		} else {
+			values["destinationAddressPrefix"] = anyOrVal(rule.DestinationAddressPrefix)
+		}
+
+		out = append(out, values)
+	}
+
+	return out
 }
 
 // RenderTerraformerChart renders the azure-infra chart with the given values.
@@ -195,12 +498,91 @@ type TerraformState struct {
 	AvailabilitySetID string
 	// AvailabilitySetName the ID for the created availability set .
 	AvailabilitySetName string
+	// WorkerPoolAvailabilitySets are the availability sets created per worker pool.
+	WorkerPoolAvailabilitySets []WorkerPoolAvailabilitySet
+	// Zoned indicates whether the cluster uses zones. Availability sets may still exist while the cluster is migrated
+	// to zones.
+	Zoned bool
 	// SubnetName is the name of the created subnet.
 	SubnetName string
+	// SubnetDualStack indicates whether the created subnet has an IPv6 address prefix.
+	SubnetDualStack bool
 	// RouteTableName is the name of the route table.
 	RouteTableName string
 	// SecurityGroupName is the name of the security group.
 	SecurityGroupName string
+	// ApplicationSecurityGroups are the created application security groups.
+	ApplicationSecurityGroups []ApplicationSecurityGroup
+	// Subnets are the created additional worker subnets.
+	Subnets []Subnet
+	// ZoneSubnets are the created worker subnets of the zones.
+	ZoneSubnets []ZoneSubnet
+	// VNetPeerings are the created vnet peerings.
+	VNetPeerings []VNetPeering
+	// PrivateDNSZoneLinks are the created links of private dns zones to the vnet.
+	PrivateDNSZoneLinks []PrivateDNSZoneLink
+	// ProximityPlacementGroups are the created proximity placement groups.
+	ProximityPlacementGroups []ProximityPlacementGroup
+}
+
+// WorkerPoolAvailabilitySet is an availability set created for a worker pool.
+type WorkerPoolAvailabilitySet struct {
+	// WorkerPool is the name of the worker pool.
+	WorkerPool string
+	// ID is the ID of the availability set.
+	ID string
+	// Name is the name of the availability set.
+	Name string
+}
+
+// ProximityPlacementGroup is a proximity placement group created for an infrastructure.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group as declared in the infrastructure config.
+	Name string
+	// Zone is the zone the proximity placement group was created for, if any.
+	Zone *string
+	// ID is the ID of the proximity placement group.
+	ID string
+}
+
+// PrivateDNSZoneLink is a link of a private dns zone to the vnet created for an infrastructure.
+type PrivateDNSZoneLink struct {
+	// PrivateDNSZoneID is the ID of the linked private dns zone.
+	PrivateDNSZoneID string
+	// ID is the ID of the virtual network link.
+	ID string
+}
+
+// VNetPeering is a vnet peering created for an infrastructure.
+type VNetPeering struct {
+	// Name is the name of the peering.
+	Name string
+	// ID is the ID of the peering.
+	ID string
+}
+
+// ZoneSubnet is the worker subnet of a zone created for an infrastructure.
+type ZoneSubnet struct {
+	// Zone is the name of the zone.
+	Zone string
+	// Name is the name of the subnet.
+	Name string
+}
+
+// Subnet is an additional worker subnet created for an infrastructure.
+type Subnet struct {
+	// Name is the name of the subnet.
+	Name string
+	// SecurityGroupName is the name of the dedicated security group of the subnet, if any.
+	SecurityGroupName string
+}
+
+// ApplicationSecurityGroup is an application security group created for an infrastructure.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string
+	// ID is the ID of the application security group.
+	ID string
 }
 
 // ExtractTerraformState extracts the TerraformState from the given Terraformer.
@@ -209,18 +591,48 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		TerraformerOutputKeyResourceGroupName,
 		TerraformerOutputKeyRouteTableName,
 		TerraformerOutputKeySecurityGroupName,
-		TerraformerOutputKeySubnetName,
 		TerraformerOutputKeyVNetName,
 	}
 
+	if len(config.Networks.Zones) > 0 {
+		for _, zone := range config.Networks.Zones {
+			outputKeys = append(outputKeys, TerraformerOutputKeyZoneSubnetNamePrefix+zone.Name)
+		}
+	} else {
+		outputKeys = append(outputKeys, TerraformerOutputKeySubnetName)
+	}
+
 	if config.Networks.VNet.Name != nil && config.Networks.VNet.ResourceGroup != nil {
 		outputKeys = append(outputKeys, TerraformerOutputKeyVNetResourceGroup)
 	}
 
-	if !config.Zoned {
+	if !config.Zoned && !config.AvailabilitySetPerWorkerPool {
 		outputKeys = append(outputKeys, TerraformerOutputKeyAvailabilitySetID, TerraformerOutputKeyAvailabilitySetName)
 	}
 
+	for _, applicationSecurityGroup := range config.Networks.ApplicationSecurityGroups {
+		outputKeys = append(outputKeys, TerraformerOutputKeyApplicationSecurityGroupIDPrefix+applicationSecurityGroup.Name)
+	}
+
+	for _, peering := range config.Networks.VNet.Peerings {
+		outputKeys = append(outputKeys, TerraformerOutputKeyVNetPeeringIDPrefix+peering.Name)
+	}
+
+	for _, proximityPlacementGroup := range config.ProximityPlacementGroups {
+		outputKeys = append(outputKeys, TerraformerOutputKeyProximityPlacementGroupIDPrefix+proximityPlacementGroupKey(proximityPlacementGroup))
+	}
+
+	for _, privateDNSZoneID := range config.Networks.VNet.PrivateDNSZoneIDs {
+		outputKeys = append(outputKeys, TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix+privateDNSZoneLinkKey(privateDNSZoneID))
+	}
+
+	for _, subnet := range config.Networks.Subnets {
+		outputKeys = append(outputKeys, TerraformerOutputKeySubnetNamePrefix+subnet.Name)
+		if subnet.SecurityGroup != nil {
+			outputKeys = append(outputKeys, TerraformerOutputKeySecurityGroupNamePrefix+subnet.Name)
+		}
+	}
+
 	vars, err := tf.GetStateOutputVariables(outputKeys...)
 	if err != nil {
 		return nil, err
@@ -232,19 +644,124 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		RouteTableName:    vars[TerraformerOutputKeyRouteTableName],
 		SecurityGroupName: vars[TerraformerOutputKeySecurityGroupName],
 		SubnetName:        vars[TerraformerOutputKeySubnetName],
+		SubnetDualStack:   config.Networks.IPv6Workers != nil,
+	}
+
+	for _, zone := range config.Networks.Zones {
+		tfState.ZoneSubnets = append(tfState.ZoneSubnets, ZoneSubnet{
+			Zone: zone.Name,
+			Name: vars[TerraformerOutputKeyZoneSubnetNamePrefix+zone.Name],
+		})
 	}
 
 	if config.Networks.VNet.Name != nil && config.Networks.VNet.ResourceGroup != nil {
 		tfState.VNetResourceGroupName = vars[TerraformerOutputKeyVNetResourceGroup]
 	}
 
-	if !config.Zoned {
+	if !config.Zoned && !config.AvailabilitySetPerWorkerPool {
 		tfState.AvailabilitySetID = vars[TerraformerOutputKeyAvailabilitySetID]
 		tfState.AvailabilitySetName = vars[TerraformerOutputKeyAvailabilitySetName]
 	}
+
+	if !config.Zoned && config.AvailabilitySetPerWorkerPool {
+		availabilitySets, err := extractWorkerPoolAvailabilitySets(tf)
+		if err != nil {
+			return nil, err
+		}
+		tfState.WorkerPoolAvailabilitySets = availabilitySets
+	}
+
+	if config.Zoned {
+		tfState.Zoned = true
+
+		// The availability sets of a cluster which is migrated to zones only exist until their machines are gone.
+		availabilitySetVars, err := tf.GetStateOutputVariables(TerraformerOutputKeyAvailabilitySetID, TerraformerOutputKeyAvailabilitySetName)
+		if err != nil && !terraformer.IsVariablesNotFoundError(err) {
+			return nil, err
+		}
+		tfState.AvailabilitySetID = availabilitySetVars[TerraformerOutputKeyAvailabilitySetID]
+		tfState.AvailabilitySetName = availabilitySetVars[TerraformerOutputKeyAvailabilitySetName]
+
+		availabilitySets, err := extractWorkerPoolAvailabilitySets(tf)
+		if err != nil && !terraformer.IsVariablesNotFoundError(err) {
+			return nil, err
+		}
+		tfState.WorkerPoolAvailabilitySets = availabilitySets
+	}
+
+	for _, applicationSecurityGroup := range config.Networks.ApplicationSecurityGroups {
+		tfState.ApplicationSecurityGroups = append(tfState.ApplicationSecurityGroups, ApplicationSecurityGroup{
+			Name: applicationSecurityGroup.Name,
+			ID:   vars[TerraformerOutputKeyApplicationSecurityGroupIDPrefix+applicationSecurityGroup.Name],
+		})
+	}
+
+	for _, peering := range config.Networks.VNet.Peerings {
+		tfState.VNetPeerings = append(tfState.VNetPeerings, VNetPeering{
+			Name: peering.Name,
+			ID:   vars[TerraformerOutputKeyVNetPeeringIDPrefix+peering.Name],
+		})
+	}
+
+	for _, proximityPlacementGroup := range config.ProximityPlacementGroups {
+		tfState.ProximityPlacementGroups = append(tfState.ProximityPlacementGroups, ProximityPlacementGroup{
+			Name: proximityPlacementGroup.Name,
+			Zone: proximityPlacementGroup.Zone,
+			ID:   vars[TerraformerOutputKeyProximityPlacementGroupIDPrefix+proximityPlacementGroupKey(proximityPlacementGroup)],
+		})
+	}
+
+	for _, privateDNSZoneID := range config.Networks.VNet.PrivateDNSZoneIDs {
+		tfState.PrivateDNSZoneLinks = append(tfState.PrivateDNSZoneLinks, PrivateDNSZoneLink{
+			PrivateDNSZoneID: privateDNSZoneID,
+			ID:               vars[TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix+privateDNSZoneLinkKey(privateDNSZoneID)],
+		})
+	}
+
+	for _, subnet := range config.Networks.Subnets {
+		tfState.Subnets = append(tfState.Subnets, Subnet{
+			Name:              vars[TerraformerOutputKeySubnetNamePrefix+subnet.Name],
+			SecurityGroupName: vars[TerraformerOutputKeySecurityGroupNamePrefix+subnet.Name],
+		})
+	}
 	return &tfState, nil
 }
 
+// extractWorkerPoolAvailabilitySets extracts the availability sets of the worker pools from the Terraform state. The
+// worker pools are taken from the state as well since they are not part of the InfrastructureConfig.
+func extractWorkerPoolAvailabilitySets(tf terraformer.Terraformer) ([]WorkerPoolAvailabilitySet, error) {
+	vars, err := tf.GetStateOutputVariables(TerraformerOutputKeyAvailabilitySetWorkerPools)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars[TerraformerOutputKeyAvailabilitySetWorkerPools]) == 0 {
+		return nil, nil
+	}
+
+	var (
+		workerPools = strings.Split(vars[TerraformerOutputKeyAvailabilitySetWorkerPools], ",")
+		outputKeys  []string
+	)
+	for _, workerPool := range workerPools {
+		outputKeys = append(outputKeys, TerraformerOutputKeyAvailabilitySetIDPrefix+workerPool, TerraformerOutputKeyAvailabilitySetNamePrefix+workerPool)
+	}
+
+	vars, err = tf.GetStateOutputVariables(outputKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	var availabilitySets []WorkerPoolAvailabilitySet
+	for _, workerPool := range workerPools {
+		availabilitySets = append(availabilitySets, WorkerPoolAvailabilitySet{
+			WorkerPool: workerPool,
+			ID:         vars[TerraformerOutputKeyAvailabilitySetIDPrefix+workerPool],
+			Name:       vars[TerraformerOutputKeyAvailabilitySetNamePrefix+workerPool],
+		})
+	}
+	return availabilitySets, nil
+}
+
 // StatusFromTerraformState computes an InfrastructureStatus from the given
 // Terraform variables.
 func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.InfrastructureStatus {
@@ -257,12 +774,6 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 			VNet: apiv1alpha1.VNetStatus{
 				Name: state.VNetName,
 			},
-			Subnets: []apiv1alpha1.Subnet{
-				{
-					Purpose: apiv1alpha1.PurposeNodes,
-					Name:    state.SubnetName,
-				},
-			},
 		},
 		AvailabilitySets: []apiv1alpha1.AvailabilitySet{},
 		RouteTables: []apiv1alpha1.RouteTable{
@@ -277,10 +788,52 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 		tfState.Networks.VNet.ResourceGroup = &state.VNetResourceGroupName
 	}
 
+	for _, peering := range state.VNetPeerings {
+		tfState.Networks.VNet.Peerings = append(tfState.Networks.VNet.Peerings, apiv1alpha1.VNetPeeringStatus{
+			Name: peering.Name,
+			ID:   peering.ID,
+		})
+	}
+
+	for _, proximityPlacementGroup := range state.ProximityPlacementGroups {
+		tfState.ProximityPlacementGroups = append(tfState.ProximityPlacementGroups, apiv1alpha1.ProximityPlacementGroupStatus{
+			Name:    proximityPlacementGroup.Name,
+			ID:      proximityPlacementGroup.ID,
+			Purpose: apiv1alpha1.PurposeNodes,
+			Zone:    proximityPlacementGroup.Zone,
+		})
+	}
+
+	for _, privateDNSZoneLink := range state.PrivateDNSZoneLinks {
+		tfState.Networks.VNet.PrivateDNSZoneLinks = append(tfState.Networks.VNet.PrivateDNSZoneLinks, apiv1alpha1.PrivateDNSZoneLinkStatus{
+			PrivateDNSZoneID: privateDNSZoneLink.PrivateDNSZoneID,
+			ID:               privateDNSZoneLink.ID,
+		})
+	}
+
+	if state.SubnetName != "" {
+		tfState.Networks.Subnets = append(tfState.Networks.Subnets, apiv1alpha1.Subnet{
+			Purpose:   apiv1alpha1.PurposeNodes,
+			Name:      state.SubnetName,
+			DualStack: state.SubnetDualStack,
+		})
+	}
+
+	for _, zoneSubnet := range state.ZoneSubnets {
+		zone := zoneSubnet.Zone
+		tfState.Networks.Subnets = append(tfState.Networks.Subnets, apiv1alpha1.Subnet{
+			Purpose: apiv1alpha1.PurposeNodes,
+			Name:    zoneSubnet.Name,
+			Zone:    &zone,
+		})
+	}
+
 	// If no AvailabilitySet was created then the Shoot uses zones.
-	if state.AvailabilitySetID == "" && state.AvailabilitySetName == "" {
+	hasSharedAvailabilitySet := state.AvailabilitySetID != "" || state.AvailabilitySetName != ""
+	if state.Zoned || (!hasSharedAvailabilitySet && len(state.WorkerPoolAvailabilitySets) == 0) {
 		tfState.Zoned = true
-	} else {
+	}
+	if hasSharedAvailabilitySet {
 		tfState.AvailabilitySets = append(tfState.AvailabilitySets, apiv1alpha1.AvailabilitySet{
 			Name:    state.AvailabilitySetName,
 			ID:      state.AvailabilitySetID,
@@ -288,6 +841,36 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 		})
 	}
 
+	for _, availabilitySet := range state.WorkerPoolAvailabilitySets {
+		workerPool := availabilitySet.WorkerPool
+		tfState.AvailabilitySets = append(tfState.AvailabilitySets, apiv1alpha1.AvailabilitySet{
+			Name:       availabilitySet.Name,
+			ID:         availabilitySet.ID,
+			Purpose:    apiv1alpha1.PurposeNodes,
+			WorkerPool: &workerPool,
+		})
+	}
+
+	for _, applicationSecurityGroup := range state.ApplicationSecurityGroups {
+		tfState.ApplicationSecurityGroups = append(tfState.ApplicationSecurityGroups, apiv1alpha1.ApplicationSecurityGroupStatus{
+			Name: applicationSecurityGroup.Name,
+			ID:   applicationSecurityGroup.ID,
+		})
+	}
+
+	for _, subnet := range state.Subnets {
+		tfState.Networks.Subnets = append(tfState.Networks.Subnets, apiv1alpha1.Subnet{
+			Purpose: apiv1alpha1.PurposeNodes,
+			Name:    subnet.Name,
+		})
+		if subnet.SecurityGroupName != "" {
+			tfState.SecurityGroups = append(tfState.SecurityGroups, apiv1alpha1.SecurityGroup{
+				Name:    subnet.SecurityGroupName,
+				Purpose: apiv1alpha1.PurposeNodes,
+			})
+		}
+	}
+
 	return &tfState
 }
 
@@ -271,6 +271,226 @@ var _ = Describe("Terraform", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(values).To(BeEquivalentTo(expectedValues))
 		})
+		It("should correctly compute the terraformer chart values for application security groups and security rules", func() {
+			var (
+				sourceAddressPrefix  = "10.250.0.0/16"
+				destinationPortRange = "443"
+			)
+
+			config.Networks.ApplicationSecurityGroups = []api.ApplicationSecurityGroup{
+				{Name: "web"},
+			}
+			config.Networks.SecurityRules = []api.SecurityRule{
+				{
+					Name:                                 "allow-https",
+					Priority:                             200,
+					Direction:                            api.SecurityRuleDirectionInbound,
+					Access:                               api.SecurityRuleAccessAllow,
+					Protocol:                             api.SecurityRuleProtocolTCP,
+					SourceAddressPrefix:                  &sourceAddressPrefix,
+					DestinationPortRange:                 &destinationPortRange,
+					DestinationApplicationSecurityGroups: []string{"web"},
+				},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("applicationSecurityGroups", []map[string]interface{}{
+				{"name": "web"},
+			}))
+			Expect(values).To(HaveKeyWithValue("securityRules", []map[string]interface{}{
+				{
+					"name":                                 "allow-https",
+					"priority":                             int32(200),
+					"direction":                            "Inbound",
+					"access":                               "Allow",
+					"protocol":                             "Tcp",
+					"sourcePortRange":                      "*",
+					"sourceAddressPrefix":                  sourceAddressPrefix,
+					"destinationPortRange":                 destinationPortRange,
+					"destinationApplicationSecurityGroups": []string{"web"},
+				},
+			}))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("applicationSecurityGroupIDPrefix", TerraformerOutputKeyApplicationSecurityGroupIDPrefix))
+		})
+
+		It("should correctly compute the terraformer chart values for a cluster with one subnet per zone", func() {
+			config.Networks.Workers = ""
+			config.Networks.Zones = []api.Zone{
+				{Name: "1", CIDR: "10.1.0.0/24"},
+				{Name: "2", CIDR: "10.1.1.0/24"},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("networks", map[string]interface{}{
+				"zones": []map[string]interface{}{
+					{"name": "1", "cidr": "10.1.0.0/24"},
+					{"name": "2", "cidr": "10.1.1.0/24"},
+				},
+			}))
+			Expect(values["outputKeys"]).NotTo(HaveKey("subnetName"))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("zoneSubnetNamePrefix", TerraformerOutputKeyZoneSubnetNamePrefix))
+		})
+
+		It("should correctly compute the terraformer chart values for vnet peerings", func() {
+			remoteVNetID := "/subscriptions/sub/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub"
+			config.Networks.VNet.Peerings = []api.VNetPeering{
+				{Name: "hub", RemoteVNetID: remoteVNetID, AllowForwardedTraffic: true, RemoteAddressSpaces: []string{"172.16.0.0/16"}},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("vnetPeerings", []map[string]interface{}{
+				{"name": "hub", "remoteVNetID": remoteVNetID, "allowForwardedTraffic": true, "useRemoteGateways": false},
+			}))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("vnetPeeringIDPrefix", TerraformerOutputKeyVNetPeeringIDPrefix))
+		})
+
+		It("should correctly compute the terraformer chart values for a dual-stack cluster", func() {
+			ipv6Workers := "fd00:10:250::/64"
+			config.Networks.IPv6Workers = &ipv6Workers
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values["resourceGroup"]).To(HaveKeyWithValue("vnet", HaveKeyWithValue("ipv6CIDR", ipv6Workers)))
+			Expect(values["networks"]).To(HaveKeyWithValue("workerIPv6", ipv6Workers))
+
+			vnetIPv6CIDR := "fd00:10:250::/48"
+			config.Networks.VNet.IPv6CIDR = &vnetIPv6CIDR
+
+			values, err = ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values["resourceGroup"]).To(HaveKeyWithValue("vnet", HaveKeyWithValue("ipv6CIDR", vnetIPv6CIDR)))
+		})
+
+		It("should correctly compute the terraformer chart values for dns servers and private dns zones", func() {
+			privateDNSZoneID := "/subscriptions/subscription_id/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+			config.Networks.VNet.DNSServers = []string{"10.1.0.4"}
+			config.Networks.VNet.PrivateDNSZoneIDs = []string{privateDNSZoneID}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values["resourceGroup"]).To(HaveKeyWithValue("vnet", HaveKeyWithValue("dnsServers", []string{"10.1.0.4"})))
+			Expect(values).To(HaveKeyWithValue("privateDNSZoneLinks", []map[string]interface{}{
+				{"key": "privatelink_blob_core_windows_net", "resourceGroup": "dns-rg", "zoneName": "privatelink.blob.core.windows.net"},
+			}))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("privateDNSZoneLinkIDPrefix", TerraformerOutputKeyPrivateDNSZoneLinkIDPrefix))
+		})
+
+		It("should correctly compute the terraformer chart values for user-defined routes", func() {
+			firewallIP := "10.250.100.4"
+			config.Networks.Routes = []api.Route{
+				{Name: "default-via-firewall", AddressPrefix: "0.0.0.0/0", NextHopType: api.RouteNextHopTypeVirtualAppliance, NextHopIPAddress: &firewallIP},
+				{Name: "onprem", AddressPrefix: "192.168.0.0/16", NextHopType: api.RouteNextHopTypeVirtualNetworkGateway},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("routes", []map[string]interface{}{
+				{"name": "default-via-firewall", "addressPrefix": "0.0.0.0/0", "nextHopType": "VirtualAppliance", "nextHopIPAddress": firewallIP},
+				{"name": "onprem", "addressPrefix": "192.168.0.0/16", "nextHopType": "VirtualNetworkGateway"},
+			}))
+		})
+
+		It("should correctly compute the terraformer chart values for proximity placement groups of a zoned cluster", func() {
+			zone1, zone2 := "1", "2"
+			config.ProximityPlacementGroups = []api.ProximityPlacementGroup{
+				{Name: "trading", Zone: &zone1},
+				{Name: "trading", Zone: &zone2},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("proximityPlacementGroups", []map[string]interface{}{
+				{"key": "trading-z1"},
+				{"key": "trading-z2"},
+			}))
+			Expect(values).NotTo(HaveKey("availabilitySet"))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("proximityPlacementGroupIDPrefix", TerraformerOutputKeyProximityPlacementGroupIDPrefix))
+		})
+
+		It("should correctly compute the terraformer chart values for the proximity placement group of a non zoned cluster", func() {
+			config.Zoned = false
+			config.ProximityPlacementGroups = []api.ProximityPlacementGroup{{Name: "trading"}}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("proximityPlacementGroups", []map[string]interface{}{
+				{"key": "trading"},
+			}))
+			Expect(values).To(HaveKeyWithValue("availabilitySet", map[string]interface{}{
+				"proximityPlacementGroup": "trading",
+			}))
+		})
+
+		It("should correctly compute the terraformer chart values for one availability set per worker pool", func() {
+			config.Zoned = false
+			config.AvailabilitySetPerWorkerPool = true
+			cluster.Shoot.Spec.Provider.Workers = []gardencorev1beta1.Worker{{Name: "cpu-worker"}, {Name: "gpu-worker"}}
+
+			// The availability set of the removed worker pool is kept.
+			removedWorkerPool := "old-worker"
+			statusJSON, err := json.Marshal(&apiv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				AvailabilitySets: []apiv1alpha1.AvailabilitySet{
+					{Name: "foo-avset-old-worker", ID: "old_id", Purpose: apiv1alpha1.PurposeNodes, WorkerPool: &removedWorkerPool},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Status.ProviderStatus = &runtime.RawExtension{Raw: statusJSON}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values["create"]).To(HaveKeyWithValue("availabilitySet", false))
+			Expect(values).To(HaveKeyWithValue("availabilitySet", map[string]interface{}{
+				"workerPools": []string{"cpu-worker", "gpu-worker", "old-worker"},
+			}))
+			Expect(values["outputKeys"]).NotTo(HaveKey("availabilitySetID"))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("availabilitySetWorkerPools", TerraformerOutputKeyAvailabilitySetWorkerPools))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("availabilitySetIDPrefix", TerraformerOutputKeyAvailabilitySetIDPrefix))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("availabilitySetNamePrefix", TerraformerOutputKeyAvailabilitySetNamePrefix))
+		})
+
+		It("should keep the availability sets of a cluster which is migrated to zones", func() {
+			workerPool := "cpu-worker"
+			statusJSON, err := json.Marshal(&apiv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				AvailabilitySets: []apiv1alpha1.AvailabilitySet{
+					{Name: "foo-avset-workers", ID: "shared_id", Purpose: apiv1alpha1.PurposeNodes},
+					{Name: "foo-avset-cpu-worker", ID: "cpu_id", Purpose: apiv1alpha1.PurposeNodes, WorkerPool: &workerPool},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Status.ProviderStatus = &runtime.RawExtension{Raw: statusJSON}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values["create"]).To(HaveKeyWithValue("availabilitySet", true))
+			Expect(values).To(HaveKeyWithValue("availabilitySet", map[string]interface{}{
+				"workerPools": []string{"cpu-worker"},
+			}))
+			Expect(values["azure"]).To(HaveKeyWithValue("countFaultDomains", countFaultDomain))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("availabilitySetID", TerraformerOutputKeyAvailabilitySetID))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("availabilitySetWorkerPools", TerraformerOutputKeyAvailabilitySetWorkerPools))
+		})
+
+		It("should correctly compute the terraformer chart values for additional subnets", func() {
+			securityGroup := "dmz-nsg"
+			config.Networks.Subnets = []api.WorkerSubnet{
+				{Name: "dmz", CIDR: "10.2.0.0/24", ServiceEndpoints: []string{testServiceEndpoint}, SecurityGroup: &securityGroup},
+				{Name: "backend", CIDR: "10.2.1.0/24"},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(HaveKeyWithValue("subnets", []map[string]interface{}{
+				{"name": "dmz", "cidr": "10.2.0.0/24", "serviceEndpoints": []string{testServiceEndpoint}, "securityGroup": securityGroup},
+				{"name": "backend", "cidr": "10.2.1.0/24", "serviceEndpoints": []string(nil)},
+			}))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("subnetNamePrefix", TerraformerOutputKeySubnetNamePrefix))
+			Expect(values["outputKeys"]).To(HaveKeyWithValue("securityGroupNamePrefix", TerraformerOutputKeySecurityGroupNamePrefix))
+		})
 	})
 
 	Describe("#StatusFromTerraformState", func() {
@@ -359,5 +579,107 @@ var _ = Describe("Terraform", func() {
 			}))
 		})
 
+		It("should correctly compute the status for one availability set per worker pool", func() {
+			state.WorkerPoolAvailabilitySets = []WorkerPoolAvailabilitySet{
+				{WorkerPool: "cpu-worker", ID: "cpu_id", Name: "cpu_name"},
+			}
+			status := StatusFromTerraformState(state)
+			workerPool := "cpu-worker"
+			Expect(status.Zoned).To(BeFalse())
+			Expect(status.AvailabilitySets).To(Equal([]apiv1alpha1.AvailabilitySet{
+				{Name: "cpu_name", ID: "cpu_id", Purpose: apiv1alpha1.PurposeNodes, WorkerPool: &workerPool},
+			}))
+		})
+
+		It("should correctly compute the status for a cluster which is migrated to zones", func() {
+			state.Zoned = true
+			state.AvailabilitySetID = availabilitySetID
+			state.AvailabilitySetName = availabilitySetName
+			status := StatusFromTerraformState(state)
+			Expect(status.Zoned).To(BeTrue())
+			Expect(status.AvailabilitySets).To(Equal([]apiv1alpha1.AvailabilitySet{
+				{Name: availabilitySetName, ID: availabilitySetID, Purpose: apiv1alpha1.PurposeNodes},
+			}))
+		})
+
+		It("should correctly compute the status for application security groups", func() {
+			state.ApplicationSecurityGroups = []ApplicationSecurityGroup{
+				{Name: "web", ID: "asg_id"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.ApplicationSecurityGroups).To(Equal([]apiv1alpha1.ApplicationSecurityGroupStatus{
+				{Name: "web", ID: "asg_id"},
+			}))
+		})
+
+		It("should correctly compute the status for a cluster with one subnet per zone", func() {
+			var zone1, zone2 = "1", "2"
+			state.SubnetName = ""
+			state.ZoneSubnets = []ZoneSubnet{
+				{Zone: zone1, Name: "subnet-z1"},
+				{Zone: zone2, Name: "subnet-z2"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.Networks.Subnets).To(Equal([]apiv1alpha1.Subnet{
+				{Name: "subnet-z1", Purpose: apiv1alpha1.PurposeNodes, Zone: &zone1},
+				{Name: "subnet-z2", Purpose: apiv1alpha1.PurposeNodes, Zone: &zone2},
+			}))
+		})
+
+		It("should correctly compute the status for vnet peerings", func() {
+			state.VNetPeerings = []VNetPeering{
+				{Name: "hub", ID: "peering_id"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.Networks.VNet.Peerings).To(Equal([]apiv1alpha1.VNetPeeringStatus{
+				{Name: "hub", ID: "peering_id"},
+			}))
+		})
+
+		It("should correctly compute the status for a dual-stack cluster", func() {
+			state.SubnetDualStack = true
+			status := StatusFromTerraformState(state)
+			Expect(status.Networks.Subnets).To(Equal([]apiv1alpha1.Subnet{
+				{Name: subnetName, Purpose: apiv1alpha1.PurposeNodes, DualStack: true},
+			}))
+		})
+
+		It("should correctly compute the status for private dns zone links", func() {
+			state.PrivateDNSZoneLinks = []PrivateDNSZoneLink{
+				{PrivateDNSZoneID: "zone_id", ID: "link_id"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.Networks.VNet.PrivateDNSZoneLinks).To(Equal([]apiv1alpha1.PrivateDNSZoneLinkStatus{
+				{PrivateDNSZoneID: "zone_id", ID: "link_id"},
+			}))
+		})
+
+		It("should correctly compute the status for proximity placement groups", func() {
+			zone := "1"
+			state.ProximityPlacementGroups = []ProximityPlacementGroup{
+				{Name: "trading", Zone: &zone, ID: "ppg_id"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.ProximityPlacementGroups).To(Equal([]apiv1alpha1.ProximityPlacementGroupStatus{
+				{Name: "trading", ID: "ppg_id", Purpose: apiv1alpha1.PurposeNodes, Zone: &zone},
+			}))
+		})
+
+		It("should correctly compute the status for additional subnets", func() {
+			state.Subnets = []Subnet{
+				{Name: "dmz", SecurityGroupName: "dmz-nsg"},
+				{Name: "backend"},
+			}
+			status := StatusFromTerraformState(state)
+			Expect(status.Networks.Subnets).To(Equal([]apiv1alpha1.Subnet{
+				{Name: subnetName, Purpose: apiv1alpha1.PurposeNodes},
+				{Name: "dmz", Purpose: apiv1alpha1.PurposeNodes},
+				{Name: "backend", Purpose: apiv1alpha1.PurposeNodes},
+			}))
+			Expect(status.SecurityGroups).To(Equal([]apiv1alpha1.SecurityGroup{
+				{Name: securityGroupName, Purpose: apiv1alpha1.PurposeNodes},
+				{Name: "dmz-nsg", Purpose: apiv1alpha1.PurposeNodes},
+			}))
+		})
 	})
 })
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/pkg/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// AnnotationKMSKeyVaultName is the annotation of the encryption configuration secret which contains the name of the
+	// key vault of the key with which the secrets are encrypted.
+	AnnotationKMSKeyVaultName = "azure.provider.extensions.gardener.cloud/kms-key-vault-name"
+	// AnnotationKMSKeyName is the annotation of the encryption configuration secret which contains the name of the key
+	// with which the secrets are encrypted.
+	AnnotationKMSKeyName = "azure.provider.extensions.gardener.cloud/kms-key-name"
+)
+
+type encryptionConfiguration struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Resources  []resourceConfiguration `json:"resources"`
+}
+
+type resourceConfiguration struct {
+	Resources []string                 `json:"resources"`
+	Providers []map[string]interface{} `json:"providers"`
+}
+
+// KMSKeyVersions returns the versions of the key vault key for which the given encryption configuration contains KMS
+// providers. Secrets are encrypted with the first key version, the others are only used to decrypt them.
+func KMSKeyVersions(data []byte) ([]string, error) {
+	config, err := decodeEncryptionConfiguration(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyVersions []string
+	for _, resource := range config.Resources {
+		if !utils.ValueExists(common.EtcdEncryptionEncryptedResourceSecrets, resource.Resources) {
+			continue
+		}
+		for _, provider := range resource.Providers {
+			if keyVersion, ok := kmsKeyVersion(provider); ok {
+				keyVersions = append(keyVersions, keyVersion)
+			}
+		}
+	}
+	return keyVersions, nil
+}
+
+// KMSEncryptionConfiguration returns an encryption configuration which encrypts secrets with the Azure Key Vault KMS
+// plugin of the first given key version. Secrets which were encrypted with the other key versions or with the other
+// providers of the given existing encryption configuration can still be decrypted. The KMS providers of the existing
+// encryption configuration are replaced by the ones of the given key versions.
+func KMSEncryptionConfiguration(keyVersions []string, existing []byte) (string, error) {
+	config, err := decodeEncryptionConfiguration(existing)
+	if err != nil {
+		return "", err
+	}
+
+	var providers []map[string]interface{}
+	for _, keyVersion := range keyVersions {
+		providers = append(providers, kmsProvider(keyVersion))
+	}
+
+	found := false
+	for i, resource := range config.Resources {
+		if !utils.ValueExists(common.EtcdEncryptionEncryptedResourceSecrets, resource.Resources) {
+			continue
+		}
+		otherProviders := providers
+		for _, provider := range resource.Providers {
+			if _, ok := kmsKeyVersion(provider); !ok {
+				otherProviders = append(otherProviders, provider)
+			}
+		}
+		config.Resources[i].Providers = otherProviders
+		found = true
+		break
+	}
+	if !found {
+		config.Resources = append(config.Resources, resourceConfiguration{
+			Resources: []string{common.EtcdEncryptionEncryptedResourceSecrets},
+			Providers: append(providers, map[string]interface{}{"identity": map[string]interface{}{}}),
+		})
+	}
+
+	// The kube-apiserver accepts encryption configurations in JSON as well.
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func decodeEncryptionConfiguration(data []byte) (*encryptionConfiguration, error) {
+	config := &encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+	}
+	if len(data) == 0 {
+		return config, nil
+	}
+
+	jsonData, err := utilyaml.ToJSON(data)
+	if err == nil {
+		err = json.Unmarshal(jsonData, config)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode encryption configuration")
+	}
+	return config, nil
+}
+
+func kmsProvider(keyVersion string) map[string]interface{} {
+	return map[string]interface{}{
+		"kms": map[string]interface{}{
+			"name":      azure.KMSProviderName(keyVersion),
+			"endpoint":  "unix://" + azure.KMSPluginSocketPath(keyVersion),
+			"cachesize": 1000,
+			"timeout":   "3s",
+		},
+	}
+}
+
+// kmsKeyVersion returns the key version of the given provider if it is a KMS provider of the Azure Key Vault KMS plugin.
+func kmsKeyVersion(provider map[string]interface{}) (string, bool) {
+	kms, ok := provider["kms"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := kms["name"].(string)
+	if !ok || !strings.HasPrefix(name, azure.KMSProviderNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, azure.KMSProviderNamePrefix), true
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KMS", func() {
+	Describe("#KMSEncryptionConfiguration", func() {
+		It("should encrypt secrets with the first key version", func() {
+			config, err := KMSEncryptionConfiguration([]string{"0123456789abcdef0123456789abcdef"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(MatchYAML(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/azurekms-0123456789abcdef0123456789abcdef.socket
+      name: azure-keyvault-0123456789abcdef0123456789abcdef
+      timeout: 3s
+  - identity: {}
+  resources:
+  - secrets
+`))
+		})
+
+		It("should replace the key versions and keep the other providers for decryption", func() {
+			config, err := KMSEncryptionConfiguration([]string{"0123456789abcdef0123456789abcdef"}, []byte(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - kms:
+      name: azure-keyvault-fedcba9876543210fedcba9876543210
+  - aescbc:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+  - identity: {}
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(MatchYAML(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/azurekms-0123456789abcdef0123456789abcdef.socket
+      name: azure-keyvault-0123456789abcdef0123456789abcdef
+      timeout: 3s
+  - aescbc:
+      keys:
+      - name: key1
+        secret: c2VjcmV0
+  - identity: {}
+  resources:
+  - secrets
+`))
+		})
+	})
+
+	Describe("#KMSKeyVersions", func() {
+		It("should return the key versions of the KMS providers in their order", func() {
+			Expect(KMSKeyVersions([]byte(`{"resources":[{"resources":["secrets"],"providers":[` +
+				`{"kms":{"name":"azure-keyvault-0123456789abcdef0123456789abcdef"}},` +
+				`{"kms":{"name":"other"}},` +
+				`{"kms":{"name":"azure-keyvault-fedcba9876543210fedcba9876543210"}},` +
+				`{"identity":{}}]}]}`))).To(Equal([]string{"0123456789abcdef0123456789abcdef", "fedcba9876543210fedcba9876543210"}))
+		})
+
+		It("should return no key versions for an empty encryption configuration", func() {
+			Expect(KMSKeyVersions(nil)).To(BeEmpty())
+		})
+	})
+})
@@ -15,7 +15,9 @@
 package controlplane
 
 import (
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal/imagevector"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
@@ -27,17 +29,33 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the Azure controlplane webhook to the manager.
+type AddOptions struct {
+	// ACRCredentialProvider contains the binary of the image credential provider for Azure Container Registries.
+	ACRCredentialProvider *config.ACRCredentialProvider
+}
+
 var logger = log.Log.WithName("azure-controlplane-webhook")
 
-// AddToManager creates a webhook and adds it to the manager.
-func AddToManager(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
+// AddToManagerWithOptions creates a webhook with the given options and adds it to the manager.
+func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) (*extensionswebhook.Webhook, error) {
 	logger.Info("Adding webhook to manager")
 	fciCodec := controlplane.NewFileContentInlineCodec()
 	return controlplane.Add(mgr, controlplane.AddArgs{
 		Kind:     controlplane.KindShoot,
 		Provider: azure.Type,
 		Types:    []runtime.Object{&appsv1.Deployment{}, &extensionsv1alpha1.OperatingSystemConfig{}},
-		Mutator: genericmutator.NewMutator(NewEnsurer(logger), controlplane.NewUnitSerializer(),
+		Mutator: genericmutator.NewMutator(NewEnsurer(opts.ACRCredentialProvider, imagevector.ImageVector(), logger), controlplane.NewUnitSerializer(),
 			controlplane.NewKubeletConfigCodec(fciCodec), fciCodec, logger),
 	})
 }
+
+// AddToManager creates a webhook with the default options and adds it to the manager.
+func AddToManager(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
+	return AddToManagerWithOptions(mgr, DefaultAddOptions)
+}
@@ -16,14 +16,27 @@ package controlplane
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
 
 	"github.com/coreos/go-systemd/unit"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	versionutils "github.com/gardener/gardener/pkg/utils/version"
 	"github.com/go-logr/logr"
@@ -31,21 +44,29 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(acrCredentialProvider *config.ACRCredentialProvider, imageVector imagevector.ImageVector, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		logger: logger.WithName("azure-controlplane-ensurer"),
+		acrCredentialProvider: acrCredentialProvider,
+		imageVector:           imageVector,
+		logger:                logger.WithName("azure-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	client client.Client
-	logger logr.Logger
+	acrCredentialProvider *config.ACRCredentialProvider
+	imageVector           imagevector.ImageVector
+	client                client.Client
+	logger                logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -64,9 +85,61 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, ectx generi
 		return err
 	}
 
+	externalCloudProvider, err := internal.IsExternalCloudProviderEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+	csiEnabled, err := internal.IsCSIMigrationComplete(ctx, e.client, dep.Namespace, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
+	cpConfig, err := helper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	applyAzureAD := false
+	if cpConfig.AzureAD != nil {
+		if applyAzureAD, err = e.canApplyAzureAD(ctx, dep.Namespace, cluster, cpConfig.AzureAD); err != nil {
+			return err
+		}
+	}
+
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
-		ensureKubeAPIServerCommandLineArgs(c)
-		ensureVolumeMounts(c, cluster.Shoot.Spec.Kubernetes.Version)
+		ensureKubeAPIServerCommandLineArgs(c, externalCloudProvider, csiEnabled)
+		if applyAzureAD {
+			ensureAzureADCommandLineArgs(c, cpConfig.AzureAD)
+		}
+		if externalCloudProvider {
+			// The kube-apiserver does not talk to Azure anymore once the in-tree cloud provider is disabled.
+			c.VolumeMounts = extensionswebhook.EnsureNoVolumeMountWithName(c.VolumeMounts, cloudProviderConfigVolumeMount.Name)
+		} else {
+			ensureVolumeMounts(c, cluster.Shoot.Spec.Kubernetes.Version)
+		}
+	}
+
+	// The KMS plugins are configured from the deployed encryption configuration instead of the control plane config, so
+	// that they are kept as long as secrets are encrypted with their key versions.
+	kmsEnabled := true
+	kmsSecret := &corev1.Secret{}
+	if err := e.client.Get(ctx, kutil.Key(dep.Namespace, azure.KMSEncryptionConfigurationName), kmsSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "could not get secret '%s/%s'", dep.Namespace, azure.KMSEncryptionConfigurationName)
+		}
+		kmsEnabled = false
+	}
+	if kmsEnabled {
+		if err := e.ensureKMSPlugins(ps, kmsSecret, cluster.Shoot.Spec.Kubernetes.Version); err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&template.ObjectMeta, "checksum/secret-"+azure.KMSEncryptionConfigurationName, util.ComputeChecksum(kmsSecret.Data))
+	}
+
+	// The KMS plugins still read the credentials from the cloud provider config.
+	if externalCloudProvider && !kmsEnabled {
+		ps.Volumes = extensionswebhook.EnsureNoVolumeWithName(ps.Volumes, cloudProviderConfigVolume.Name)
+		return nil
 	}
 	ensureVolumes(ps, cluster.Shoot.Spec.Kubernetes.Version)
 	return e.ensureChecksumAnnotations(ctx, &dep.Spec.Template, dep.Namespace)
@@ -82,8 +155,13 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, ect
 		return err
 	}
 
+	csiEnabled, err := internal.IsCSIMigrationComplete(ctx, e.client, dep.Namespace, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-controller-manager"); c != nil {
-		ensureKubeControllerManagerCommandLineArgs(c)
+		ensureKubeControllerManagerCommandLineArgs(c, csiEnabled)
 		ensureVolumeMounts(c, cluster.Shoot.Spec.Kubernetes.Version)
 	}
 	ensureKubeControllerManagerAnnotations(template)
@@ -91,23 +169,216 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, ect
 	return e.ensureChecksumAnnotations(ctx, &dep.Spec.Template, dep.Namespace)
 }
 
-func ensureKubeAPIServerCommandLineArgs(c *corev1.Container) {
-	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "azure")
-	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-config=",
-		"/etc/kubernetes/cloudprovider/cloudprovider.conf")
+// EnsureKubeSchedulerDeployment ensures that the kube-scheduler deployment conforms to the provider requirements.
+func (e *ensurer) EnsureKubeSchedulerDeployment(ctx context.Context, ectx genericmutator.EnsurerContext, dep *appsv1.Deployment) error {
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	csiEnabled, err := internal.IsCSIMigrationComplete(ctx, e.client, dep.Namespace, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
+	if c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-scheduler"); c != nil && csiEnabled {
+		ensureCSIMigrationFeatureGates(c)
+	}
+	return nil
+}
+
+func ensureKubeAPIServerCommandLineArgs(c *corev1.Container, externalCloudProvider, csiEnabled bool) {
+	if externalCloudProvider {
+		// The nodes are labeled by the cloud-node-manager, hence, the in-tree cloud provider is not needed anymore.
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--cloud-provider=")
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--cloud-config=")
+	} else {
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "azure")
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-config=",
+			"/etc/kubernetes/cloudprovider/cloudprovider.conf")
+	}
+
+	if csiEnabled {
+		// The persistent volumes are labeled by the CSI drivers, hence, the PersistentVolumeLabel admission plugin is
+		// not needed anymore.
+		c.Command = extensionswebhook.EnsureNoStringWithPrefixContains(c.Command, "--enable-admission-plugins=",
+			"PersistentVolumeLabel", ",")
+		c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--disable-admission-plugins=",
+			"PersistentVolumeLabel", ",")
+		ensureCSIMigrationFeatureGates(c)
+		return
+	}
 	c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--enable-admission-plugins=",
 		"PersistentVolumeLabel", ",")
 	c.Command = extensionswebhook.EnsureNoStringWithPrefixContains(c.Command, "--disable-admission-plugins=",
 		"PersistentVolumeLabel", ",")
 }
 
-func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container) {
+// canApplyAzureAD returns true if the Azure Active Directory authentication can be applied to the kube-apiserver. It
+// is not applied if the shoot configures another OIDC provider or if the tenant must but does not match the tenant of
+// the cloud provider credentials, the control plane actuator reports both cases in a condition of the control plane.
+func (e *ensurer) canApplyAzureAD(ctx context.Context, namespace string, cluster *extensionscontroller.Cluster, azureAD *apisazure.AzureADConfig) (bool, error) {
+	if internal.HasOIDCConfig(cluster.Shoot) {
+		e.logger.Info("Not applying the azure active directory authentication as the shoot configures another OIDC provider", "namespace", namespace)
+		return false, nil
+	}
+	if azureAD.ValidateTenant == nil || !*azureAD.ValidateTenant {
+		return true, nil
+	}
+
+	secretRef := corev1.SecretReference{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}
+	ca, err := internal.GetClientAuthData(ctx, e.client, secretRef)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get service account from secret '%s/%s'", secretRef.Namespace, secretRef.Name)
+	}
+	if !internal.IsAzureADTenantValid(azureAD, ca) {
+		e.logger.Info("Not applying the azure active directory authentication as its tenant does not match the tenant of the cloud provider credentials", "namespace", namespace)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ensureAzureADCommandLineArgs configures the kube-apiserver to accept the ID tokens which Azure Active Directory issues
+// for the given application.
+func ensureAzureADCommandLineArgs(c *corev1.Container, azureAD *apisazure.AzureADConfig) {
+	usernameClaim, groupsClaim := "oid", "groups"
+	if azureAD.UsernameClaim != nil {
+		usernameClaim = *azureAD.UsernameClaim
+	}
+	if azureAD.GroupsClaim != nil {
+		groupsClaim = *azureAD.GroupsClaim
+	}
+
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-issuer-url=",
+		fmt.Sprintf("https://login.microsoftonline.com/%s/v2.0", azureAD.TenantID))
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-client-id=", azureAD.ClientID)
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-username-claim=", usernameClaim)
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-groups-claim=", groupsClaim)
+
+	// Settings of another identity provider which is configured in the shoot do not apply to Azure Active Directory. Its
+	// tokens are signed with RS256, the default signing algorithm, and its certificates are signed by well-known CAs.
+	c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--oidc-ca-file=")
+	c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--oidc-signing-algs=")
+	c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--oidc-required-claim=")
+
+	if azureAD.UsernamePrefix != nil {
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-username-prefix=", *azureAD.UsernamePrefix)
+	} else {
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--oidc-username-prefix=")
+	}
+	if azureAD.GroupsPrefix != nil {
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--oidc-groups-prefix=", *azureAD.GroupsPrefix)
+	} else {
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--oidc-groups-prefix=")
+	}
+}
+
+// ensureKMSPlugins adds the Azure Key Vault KMS plugins as sidecars to the kube-apiserver and makes the kube-apiserver
+// use the encryption configuration which is deployed with the control plane chart. A plugin is added for every key
+// version of the encryption configuration so that secrets which were encrypted before a key rotation can still be
+// decrypted until they are rewritten with the current key version.
+func (e *ensurer) ensureKMSPlugins(ps *corev1.PodSpec, secret *corev1.Secret, version string) error {
+	keyVersions, err := internal.KMSKeyVersions(secret.Data[azure.KMSEncryptionConfigurationKey])
+	if err != nil {
+		return errors.Wrapf(err, "could not get key versions of secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+
+	image, err := e.imageVector.FindImage(azure.KMSPluginImageName, imagevector.TargetVersion(version))
+	if err != nil {
+		return errors.Wrapf(err, "could not find image %s", azure.KMSPluginImageName)
+	}
+
+	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--encryption-provider-config=",
+			filepath.Join(kmsEncryptionConfigurationVolumeMount.MountPath, azure.KMSEncryptionConfigurationKey))
+		c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, kmsEncryptionConfigurationVolumeMount)
+		c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, kmsSocketVolumeMount)
+	}
+
+	keyVaultName, keyName := secret.Annotations[internal.AnnotationKMSKeyVaultName], secret.Annotations[internal.AnnotationKMSKeyName]
+	names := sets.NewString()
+	for i, keyVersion := range keyVersions {
+		name := kmsPluginName
+		if i > 0 {
+			name = kmsPluginName + "-" + keyVersion
+		}
+		names.Insert(name)
+		ps.Containers = extensionswebhook.EnsureContainerWithName(ps.Containers,
+			getKMSPluginContainer(name, image.String(), keyVaultName, keyName, keyVersion, 8787+i))
+	}
+	var containers []corev1.Container
+	for _, c := range ps.Containers {
+		if !strings.HasPrefix(c.Name, kmsPluginName) || names.Has(c.Name) {
+			containers = append(containers, c)
+		}
+	}
+	ps.Containers = containers
+
+	ps.Volumes = extensionswebhook.EnsureVolumeWithName(ps.Volumes, kmsEncryptionConfigurationVolume)
+	ps.Volumes = extensionswebhook.EnsureVolumeWithName(ps.Volumes, kmsSocketVolume)
+	return nil
+}
+
+func getKMSPluginContainer(name, image, keyVaultName, keyName, keyVersion string, healthzPort int) corev1.Container {
+	return corev1.Container{
+		Name:  name,
+		Image: image,
+		Args: []string{
+			"--listen-addr=unix://" + azure.KMSPluginSocketPath(keyVersion),
+			"--keyvault-name=" + keyVaultName,
+			"--key-name=" + keyName,
+			"--key-version=" + keyVersion,
+			"--config-file-path=" + filepath.Join(cloudProviderConfigVolumeMount.MountPath, azure.CloudProviderConfigMapKey),
+			"--healthz-port=" + strconv.Itoa(healthzPort),
+			"--healthz-path=/healthz",
+		},
+		LivenessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz",
+					Port: intstr.FromInt(healthzPort),
+				},
+			},
+			InitialDelaySeconds: 15,
+			PeriodSeconds:       10,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			cloudProviderConfigVolumeMount,
+			kmsSocketVolumeMount,
+		},
+	}
+}
+
+func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container, csiEnabled bool) {
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "external")
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-config=",
 		"/etc/kubernetes/cloudprovider/cloudprovider.conf")
+
+	if csiEnabled {
+		// Azure disks and files are handled by the CSI drivers, hence, the in-tree volume plugin is not needed anymore.
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--external-cloud-volume-plugin=")
+		ensureCSIMigrationFeatureGates(c)
+		return
+	}
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--external-cloud-volume-plugin=", "azure")
 }
 
+func ensureCSIMigrationFeatureGates(c *corev1.Container) {
+	c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--feature-gates=", "CSIMigration=true", ",")
+	c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--feature-gates=", "CSIMigrationAzureDisk=true", ",")
+	c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--feature-gates=", "CSIMigrationAzureFile=true", ",")
+}
+
 func ensureKubeControllerManagerAnnotations(t *corev1.PodTemplateSpec) {
 	t.Labels = extensionswebhook.EnsureAnnotationOrLabel(t.Labels, v1beta1constants.LabelNetworkPolicyToPublicNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
 	t.Labels = extensionswebhook.EnsureAnnotationOrLabel(t.Labels, v1beta1constants.LabelNetworkPolicyToPrivateNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
@@ -137,13 +408,43 @@ var (
 	cloudProviderConfigVolume = corev1.Volume{
 		Name: azure.CloudProviderConfigName,
 		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: azure.CloudProviderConfigName},
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: azure.CloudProviderConfigName,
 			},
 		},
 	}
 )
 
+const kmsPluginName = "kms-plugin"
+
+var (
+	kmsEncryptionConfigurationVolumeMount = corev1.VolumeMount{
+		Name:      azure.KMSEncryptionConfigurationName,
+		MountPath: "/etc/kubernetes/kms-encryption-configuration",
+		ReadOnly:  true,
+	}
+	kmsEncryptionConfigurationVolume = corev1.Volume{
+		Name: azure.KMSEncryptionConfigurationName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: azure.KMSEncryptionConfigurationName,
+			},
+		},
+	}
+
+	kmsSocketName        = "kms-socket"
+	kmsSocketVolumeMount = corev1.VolumeMount{
+		Name:      kmsSocketName,
+		MountPath: azure.KMSPluginSocketDir,
+	}
+	kmsSocketVolume = corev1.Volume{
+		Name: kmsSocketName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+)
+
 func ensureVolumeMounts(c *corev1.Container, version string) {
 	c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, cloudProviderConfigVolumeMount)
 
@@ -173,27 +474,104 @@ func mustMountEtcSSLFolder(version string) bool {
 }
 
 func (e *ensurer) ensureChecksumAnnotations(ctx context.Context, template *corev1.PodTemplateSpec, namespace string) error {
-	return controlplane.EnsureConfigMapChecksumAnnotation(ctx, template, e.client, namespace, azure.CloudProviderConfigName)
+	return controlplane.EnsureSecretChecksumAnnotation(ctx, template, e.client, namespace, azure.CloudProviderConfigName)
 }
 
 // EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 func (e *ensurer) EnsureKubeletServiceUnitOptions(ctx context.Context, ectx genericmutator.EnsurerContext, opts []*unit.UnitOption) ([]*unit.UnitOption, error) {
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	externalCloudProvider, err := internal.IsExternalCloudProviderEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	cpConfig, err := helper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var acrCredentialProviderBinDir string
+	if cpConfig.ACRCredentialProvider != nil {
+		if e.acrCredentialProvider == nil {
+			return nil, errAcrCredentialProviderNotAvailable
+		}
+		acrCredentialProviderBinDir = getAcrCredentialProviderBinDir(e.acrCredentialProvider)
+
+		// The kubelet must not start before the credential provider is installed.
+		opts = extensionswebhook.EnsureUnitOption(opts, &unit.UnitOption{Section: "Unit", Name: "Requires", Value: acrCredentialProviderInstallerUnitName})
+		opts = extensionswebhook.EnsureUnitOption(opts, &unit.UnitOption{Section: "Unit", Name: "After", Value: acrCredentialProviderInstallerUnitName})
+	}
+
 	if opt := extensionswebhook.UnitOptionWithSectionAndName(opts, "Service", "ExecStart"); opt != nil {
 		command := extensionswebhook.DeserializeCommandLine(opt.Value)
-		command = ensureKubeletCommandLineArgs(command)
+		command = ensureKubeletCommandLineArgs(command, externalCloudProvider, acrCredentialProviderBinDir)
 		opt.Value = extensionswebhook.SerializeCommandLine(command, 1, " \\\n    ")
 	}
 	return opts, nil
 }
 
-func ensureKubeletCommandLineArgs(command []string) []string {
-	command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "azure")
-	command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-config=", "/var/lib/kubelet/cloudprovider.conf")
+func ensureKubeletCommandLineArgs(command []string, externalCloudProvider bool, acrCredentialProviderBinDir string) []string {
+	if externalCloudProvider {
+		// The node is initialized by the cloud-node-manager which runs as DaemonSet in the shoot.
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "external")
+		command = extensionswebhook.EnsureNoStringWithPrefix(command, "--cloud-config=")
+	} else {
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "azure")
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-config=", "/var/lib/kubelet/cloudprovider.conf")
+	}
+	if len(acrCredentialProviderBinDir) > 0 {
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--image-credential-provider-config=", acrCredentialProviderConfigPath)
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--image-credential-provider-bin-dir=", acrCredentialProviderBinDir)
+	}
 	return command
 }
 
 // EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
 func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, ectx genericmutator.EnsurerContext, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) error {
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cpConfig, err := helper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	if cpConfig.ACRCredentialProvider != nil {
+		// The feature gate is removed from the kubelet as of Kubernetes 1.28 as the image credential providers are
+		// always enabled.
+		hasFeatureGate, err := versionutils.CheckVersionMeetsConstraint(cluster.Shoot.Spec.Kubernetes.Version, ">= 1.20, < 1.28")
+		if err != nil {
+			return err
+		}
+		if hasFeatureGate {
+			if kubeletConfig.FeatureGates == nil {
+				kubeletConfig.FeatureGates = make(map[string]bool)
+			}
+			kubeletConfig.FeatureGates["KubeletCredentialProviders"] = true
+		}
+	}
+
+	csiEnabled, err := internal.IsCSIMigrationEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
+	if csiEnabled {
+		if kubeletConfig.FeatureGates == nil {
+			kubeletConfig.FeatureGates = make(map[string]bool)
+		}
+		kubeletConfig.FeatureGates["CSIMigration"] = true
+		kubeletConfig.FeatureGates["CSIMigrationAzureDisk"] = true
+		kubeletConfig.FeatureGates["CSIMigrationAzureFile"] = true
+		return nil
+	}
+
 	// Make sure CSI-related feature gates are not enabled
 	// TODO Leaving these enabled shouldn't do any harm, perhaps remove this code when properly tested?
 	delete(kubeletConfig.FeatureGates, "VolumeSnapshotDataSource")
@@ -202,6 +580,121 @@ func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, ectx genericmu
 	return nil
 }
 
+const (
+	acrCredentialProviderName                      = "acr-credential-provider"
+	acrCredentialProviderConfigPath                = "/var/lib/kubelet/credential-provider-config.yaml"
+	acrCredentialProviderManagedIdentityConfigPath = "/var/lib/kubelet/acr-credential-provider.conf"
+	acrCredentialProviderInstallerUnitName         = "acr-credential-provider-installer.service"
+)
+
+var errAcrCredentialProviderNotAvailable = errors.New("the image credential provider for Azure Container Registries is not available in this landscape")
+
+// getAcrCredentialProviderBinDir returns the directory of the credential provider binary. It contains the digest of
+// the binary so that a new binary is installed when it changes.
+func getAcrCredentialProviderBinDir(provider *config.ACRCredentialProvider) string {
+	return "/opt/bin/acr-credential-provider/" + provider.SHA256
+}
+
+// EnsureAdditionalUnits ensures that additional required system units are added.
+func (e *ensurer) EnsureAdditionalUnits(ctx context.Context, ectx genericmutator.EnsurerContext, units *[]extensionsv1alpha1.Unit) error {
+	cpConfig, err := e.controlPlaneConfig(ctx, ectx)
+	if err != nil {
+		return err
+	}
+
+	if cpConfig.ACRCredentialProvider == nil {
+		return nil
+	}
+	if e.acrCredentialProvider == nil {
+		return errAcrCredentialProviderNotAvailable
+	}
+
+	// The binary is only installed if it has the configured digest. The installation is retried in the unit itself as
+	// systemd versions < 244 do not restart units of type oneshot.
+	binDir := getAcrCredentialProviderBinDir(e.acrCredentialProvider)
+	binary := filepath.Join(binDir, acrCredentialProviderName)
+	content := `[Unit]
+Description=Installs the image credential provider of the kubelet for Azure Container Registries
+Wants=network-online.target
+After=network-online.target
+Before=kubelet.service
+[Install]
+WantedBy=kubelet.service
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh -c "until test -x ` + binary + ` || (mkdir -p ` + binDir + ` && curl -sSfL --retry 5 -o ` + binary + `.tmp '` + e.acrCredentialProvider.URL + `' && echo '` + e.acrCredentialProvider.SHA256 + `  ` + binary + `.tmp' | sha256sum -c - && chmod 0755 ` + binary + `.tmp && mv ` + binary + `.tmp ` + binary + `); do sleep 10; done"
+`
+	extensionswebhook.AppendUniqueUnit(units, extensionsv1alpha1.Unit{
+		Name:    acrCredentialProviderInstallerUnitName,
+		Enable:  util.BoolPtr(true),
+		Command: util.StringPtr("start"),
+		Content: &content,
+	})
+	return nil
+}
+
+// EnsureAdditionalFiles ensures that additional required system files are added.
+func (e *ensurer) EnsureAdditionalFiles(ctx context.Context, ectx genericmutator.EnsurerContext, files *[]extensionsv1alpha1.File) error {
+	cpConfig, err := e.controlPlaneConfig(ctx, ectx)
+	if err != nil {
+		return err
+	}
+
+	provider := cpConfig.ACRCredentialProvider
+	if provider == nil {
+		return nil
+	}
+
+	// The credential provider authenticates with the managed identity of the nodes, hence, no credentials are written
+	// to the nodes.
+	*files = extensionswebhook.EnsureFileWithPath(*files, extensionsv1alpha1.File{
+		Path:        acrCredentialProviderManagedIdentityConfigPath,
+		Permissions: util.Int32Ptr(0644),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: "useManagedIdentityExtension: true\nuserAssignedIdentityID: " + provider.ManagedIdentityClientID + "\n",
+			},
+		},
+	})
+
+	config, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "kubelet.config.k8s.io/v1alpha1",
+		"kind":       "CredentialProviderConfig",
+		"providers": []interface{}{
+			map[string]interface{}{
+				"name":                 acrCredentialProviderName,
+				"apiVersion":           "credentialprovider.kubelet.k8s.io/v1alpha1",
+				"matchImages":          provider.Registries,
+				"defaultCacheDuration": "10m",
+				"args":                 []string{acrCredentialProviderManagedIdentityConfigPath},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	*files = extensionswebhook.EnsureFileWithPath(*files, extensionsv1alpha1.File{
+		Path:        acrCredentialProviderConfigPath,
+		Permissions: util.Int32Ptr(0644),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: string(config),
+			},
+		},
+	})
+	return nil
+}
+
+func (e *ensurer) controlPlaneConfig(ctx context.Context, ectx genericmutator.EnsurerContext) (*apisazure.ControlPlaneConfig, error) {
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return helper.ControlPlaneConfigFromCluster(cluster)
+}
+
 // ShouldProvisionKubeletCloudProviderConfig returns true if the cloud provider config file should be added to the kubelet configuration.
 func (e *ensurer) ShouldProvisionKubeletCloudProviderConfig() bool {
 	return true
@@ -16,9 +16,12 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -29,6 +32,8 @@ import (
 	"github.com/coreos/go-systemd/unit"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -79,15 +84,67 @@ var _ = Describe("Ensurer", func() {
 				},
 			},
 		)
+		eContextK8s121 = genericmutator.NewInternalEnsurerContext(
+			&extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{
+							Version: "1.21.0",
+						},
+					},
+				},
+			},
+		)
+
+		eContextK8s120ACR = genericmutator.NewInternalEnsurerContext(
+			&extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{
+							Version: "1.20.4",
+						},
+						Provider: gardencorev1beta1.Provider{
+							ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+								RawExtension: runtime.RawExtension{
+									Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","acrCredentialProvider":{"registries":["myregistry.azurecr.io"],"managedIdentityClientID":"00000000-1111-2222-3333-444444444444","managedIdentityID":"/subscriptions/sub/resourceGroups/identities/providers/Microsoft.ManagedIdentity/userAssignedIdentities/nodes"}}`),
+								},
+							},
+						},
+					},
+				},
+			},
+		)
+
+		acrCredentialProvider = &config.ACRCredentialProvider{
+			URL:    "https://example.com/azure-acr-credential-provider-linux-amd64",
+			SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		}
 
-		cmKey = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderConfigName}
-		cm    = &corev1.ConfigMap{
+		imageVector = imagevector.ImageVector{
+			{
+				Name:       azure.KMSPluginImageName,
+				Repository: "mcr.microsoft.com/oss/azure/kms/keyvault",
+				Tag:        util.StringPtr("v0.0.10"),
+			},
+		}
+
+		secretKey    = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderConfigName}
+		kmsSecretKey = client.ObjectKey{Namespace: namespace, Name: azure.KMSEncryptionConfigurationName}
+		secret       = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderConfigName},
-			Data:       map[string]string{"abc": "xyz", azure.CloudProviderConfigMapKey: cloudProviderConfigContent},
+			Data:       map[string][]byte{"abc": []byte("xyz"), azure.CloudProviderConfigMapKey: []byte(cloudProviderConfigContent)},
+		}
+
+		clusterKey      = client.ObjectKey{Name: namespace}
+		migratedCluster = &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        namespace,
+				Annotations: map[string]string{internal.AnnotationCSIMigrationComplete: "true"},
+			},
 		}
 
 		annotations = map[string]string{
-			"checksum/configmap-" + azure.CloudProviderConfigName: "31d2e116fbf854a590e84ab9176f299af6ff86aeea61bcee6bd705de78da9bf3",
+			"checksum/secret-" + azure.CloudProviderConfigName: "546bca950d25ff0b53fe8b7d7e2cee183f61524d4e3207f9e4db953ee06bc48d",
 		}
 
 		kubeControllerManagerLabels = map[string]string{
@@ -126,10 +183,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -159,10 +217,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -172,6 +231,332 @@ var _ = Describe("Ensurer", func() {
 			checkKubeAPIServerDeployment(dep, annotations, false)
 		})
 
+		It("should add the KMS plugins of all key versions of the encryption configuration to kube-apiserver deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-apiserver",
+										Command: []string{"--encryption-provider-config=/etc/kubernetes/etcd-encryption-secret/encryption-configuration.yaml"},
+									},
+									{Name: "kms-plugin-00000000000000000000000000000000"},
+								},
+							},
+						},
+					},
+				}
+
+				kmsSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      azure.KMSEncryptionConfigurationName,
+						Annotations: map[string]string{
+							internal.AnnotationKMSKeyVaultName: "my-vault",
+							internal.AnnotationKMSKeyName:      "etcd-encryption",
+						},
+					},
+					Data: map[string][]byte{azure.KMSEncryptionConfigurationKey: []byte(`{"resources":[{"resources":["secrets"],"providers":[` +
+						`{"kms":{"name":"azure-keyvault-0123456789abcdef0123456789abcdef"}},` +
+						`{"kms":{"name":"azure-keyvault-fedcba9876543210fedcba9876543210"}},` +
+						`{"identity":{}}]}]}`)},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextK8s117, dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkKubeAPIServerDeployment(dep, map[string]string{
+				"checksum/secret-" + azure.CloudProviderConfigName:        annotations["checksum/secret-"+azure.CloudProviderConfigName],
+				"checksum/secret-" + azure.KMSEncryptionConfigurationName: "d510825f3efd2f27da0f670c039f7a94a0b87e661c8d31ec7245cba1a9029e2a",
+			}, false)
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ConsistOf("--encryption-provider-config=/etc/kubernetes/kms-encryption-configuration/encryption-configuration.yaml",
+				"--cloud-provider=azure",
+				"--cloud-config=/etc/kubernetes/cloudprovider/cloudprovider.conf",
+				"--enable-admission-plugins=PersistentVolumeLabel"))
+			Expect(c.VolumeMounts).To(ContainElement(kmsEncryptionConfigurationVolumeMount))
+			Expect(c.VolumeMounts).To(ContainElement(kmsSocketVolumeMount))
+			Expect(dep.Spec.Template.Spec.Volumes).To(ContainElement(kmsEncryptionConfigurationVolume))
+			Expect(dep.Spec.Template.Spec.Volumes).To(ContainElement(kmsSocketVolume))
+
+			kmsPlugin := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kms-plugin")
+			Expect(kmsPlugin).To(Not(BeNil()))
+			Expect(kmsPlugin.Image).To(Equal("mcr.microsoft.com/oss/azure/kms/keyvault:v0.0.10"))
+			Expect(kmsPlugin.Args).To(ConsistOf(
+				"--listen-addr=unix:///var/run/kmsplugin/azurekms-0123456789abcdef0123456789abcdef.socket",
+				"--keyvault-name=my-vault",
+				"--key-name=etcd-encryption",
+				"--key-version=0123456789abcdef0123456789abcdef",
+				"--config-file-path=/etc/kubernetes/cloudprovider/cloudprovider.conf",
+				"--healthz-port=8787",
+				"--healthz-path=/healthz",
+			))
+			Expect(kmsPlugin.VolumeMounts).To(ConsistOf(cloudProviderConfigVolumeMount, kmsSocketVolumeMount))
+
+			previousKMSPlugin := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kms-plugin-fedcba9876543210fedcba9876543210")
+			Expect(previousKMSPlugin).To(Not(BeNil()))
+			Expect(previousKMSPlugin.Args).To(ContainElement("--listen-addr=unix:///var/run/kmsplugin/azurekms-fedcba9876543210fedcba9876543210.socket"))
+			Expect(previousKMSPlugin.Args).To(ContainElement("--key-version=fedcba9876543210fedcba9876543210"))
+			Expect(previousKMSPlugin.Args).To(ContainElement("--healthz-port=8788"))
+			Expect(extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kms-plugin-00000000000000000000000000000000")).To(BeNil())
+		})
+
+		It("should add the azure active directory OIDC flags to kube-apiserver deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+										Command: []string{
+											"--oidc-issuer-url=https://issuer.example.com",
+											"--oidc-client-id=other",
+											"--oidc-ca-file=/srv/kubernetes/oidc/ca.crt",
+											"--oidc-username-prefix=other:",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				eContextAzureAD = genericmutator.NewInternalEnsurerContext(
+					&extensionscontroller.Cluster{
+						Shoot: &gardencorev1beta1.Shoot{
+							Spec: gardencorev1beta1.ShootSpec{
+								Kubernetes: gardencorev1beta1.Kubernetes{
+									Version: "1.17.0",
+								},
+								Provider: gardencorev1beta1.Provider{
+									ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+										RawExtension: runtime.RawExtension{
+											Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","azureAD":{"tenantID":"00000000-1111-2222-3333-444444444444","clientID":"55555555-6666-7777-8888-999999999999","groupsPrefix":"aad:"}}`),
+										},
+									},
+								},
+							},
+						},
+					},
+				)
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextAzureAD, dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkKubeAPIServerDeployment(dep, annotations, false)
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ContainElement("--oidc-issuer-url=https://login.microsoftonline.com/00000000-1111-2222-3333-444444444444/v2.0"))
+			Expect(c.Command).To(ContainElement("--oidc-client-id=55555555-6666-7777-8888-999999999999"))
+			Expect(c.Command).To(ContainElement("--oidc-username-claim=oid"))
+			Expect(c.Command).To(ContainElement("--oidc-groups-claim=groups"))
+			Expect(c.Command).To(ContainElement("--oidc-groups-prefix=aad:"))
+			Expect(c.Command).To(Not(ContainElement("--oidc-ca-file=/srv/kubernetes/oidc/ca.crt")))
+			Expect(c.Command).To(Not(ContainElement("--oidc-username-prefix=other:")))
+		})
+
+		It("should not apply the azure active directory authentication if it cannot be applied", func() {
+			var (
+				command = []string{
+					"--oidc-issuer-url=https://issuer.example.com",
+					"--oidc-client-id=other",
+				}
+				deploymentWithOIDCFlags = func() *appsv1.Deployment {
+					return &appsv1.Deployment{
+						ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+						Spec: appsv1.DeploymentSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{{Name: "kube-apiserver", Command: append([]string{}, command...)}},
+								},
+							},
+						},
+					}
+				}
+				contextWithAzureAD = func(validateTenant bool, oidcConfig *gardencorev1beta1.OIDCConfig) genericmutator.EnsurerContext {
+					return genericmutator.NewInternalEnsurerContext(
+						&extensionscontroller.Cluster{
+							Shoot: &gardencorev1beta1.Shoot{
+								Spec: gardencorev1beta1.ShootSpec{
+									Kubernetes: gardencorev1beta1.Kubernetes{
+										Version:       "1.17.0",
+										KubeAPIServer: &gardencorev1beta1.KubeAPIServerConfig{OIDCConfig: oidcConfig},
+									},
+									Provider: gardencorev1beta1.Provider{
+										ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+											RawExtension: runtime.RawExtension{
+												Raw: []byte(fmt.Sprintf(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","azureAD":{"tenantID":"00000000-1111-2222-3333-444444444444","clientID":"55555555-6666-7777-8888-999999999999","validateTenant":%t}}`, validateTenant)),
+											},
+										},
+									},
+								},
+							},
+						},
+					)
+				}
+				cloudProviderSecretKey = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}
+				cloudProviderSecret    = &corev1.Secret{
+					Data: map[string][]byte{
+						azure.TenantIDKey:       []byte("aaaaaaaa-1111-2222-3333-444444444444"),
+						azure.SubscriptionIDKey: []byte("subscription"),
+						azure.ClientIDKey:       []byte("client"),
+						azure.ClientSecretKey:   []byte("secret"),
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName)).Times(2)
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret)).Times(2)
+			client.EXPECT().Get(context.TODO(), cloudProviderSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cloudProviderSecret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// The shoot configures another OIDC provider
+			dep := deploymentWithOIDCFlags()
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), contextWithAzureAD(false, &gardencorev1beta1.OIDCConfig{}), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ContainElement("--oidc-issuer-url=https://issuer.example.com"))
+			Expect(c.Command).To(ContainElement("--oidc-client-id=other"))
+
+			// The tenant does not match the tenant of the cloud provider credentials
+			dep = deploymentWithOIDCFlags()
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), contextWithAzureAD(true, nil), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			c = extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ContainElement("--oidc-issuer-url=https://issuer.example.com"))
+			Expect(c.Command).To(ContainElement("--oidc-client-id=other"))
+		})
+
+		It("should run kube-apiserver deployment with the external cloud provider once the nodes are migrated (k8s >= 1.21)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+										Command: []string{
+											"--cloud-provider=azure",
+											"--cloud-config=/etc/kubernetes/cloudprovider/cloudprovider.conf",
+											"--enable-admission-plugins=Priority,PersistentVolumeLabel",
+											"--feature-gates=Foo=true",
+										},
+										VolumeMounts: []corev1.VolumeMount{cloudProviderConfigVolumeMount},
+									},
+								},
+								Volumes: []corev1.Volume{cloudProviderConfigVolume},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(migratedCluster))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextK8s121, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ConsistOf(
+				"--enable-admission-plugins=Priority",
+				"--disable-admission-plugins=PersistentVolumeLabel",
+				"--feature-gates=Foo=true,CSIMigration=true,CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true",
+			))
+			Expect(c.VolumeMounts).To(BeEmpty())
+			Expect(dep.Spec.Template.Spec.Volumes).To(BeEmpty())
+			Expect(dep.Spec.Template.Annotations).To(BeEmpty())
+		})
+
+		It("should run kube-apiserver deployment with the external cloud provider but keep the in-tree volume labeling until the nodes are migrated (k8s >= 1.21)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+										Command: []string{
+											"--cloud-provider=azure",
+											"--cloud-config=/etc/kubernetes/cloudprovider/cloudprovider.conf",
+											"--enable-admission-plugins=Priority",
+										},
+										VolumeMounts: []corev1.VolumeMount{cloudProviderConfigVolumeMount},
+									},
+								},
+								Volumes: []corev1.Volume{cloudProviderConfigVolume},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(&extensionsv1alpha1.Cluster{}))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextK8s121, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c.Command).To(ConsistOf("--enable-admission-plugins=Priority,PersistentVolumeLabel"))
+			Expect(c.VolumeMounts).To(BeEmpty())
+			Expect(dep.Spec.Template.Spec.Volumes).To(BeEmpty())
+		})
+
 		It("should modify existing elements of kube-apiserver deployment", func() {
 			var (
 				dep = &appsv1.Deployment{
@@ -204,10 +589,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.KMSEncryptionConfigurationName))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -239,10 +625,10 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -272,10 +658,10 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -285,6 +671,84 @@ var _ = Describe("Ensurer", func() {
 			checkKubeControllerManagerDeployment(dep, annotations, kubeControllerManagerLabels, false)
 		})
 
+		It("should migrate kube-controller-manager deployment to the CSI driver once the nodes are migrated (k8s >= 1.21)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeControllerManager},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-controller-manager",
+										Command: []string{"--external-cloud-volume-plugin=azure"},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(migratedCluster))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err = ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), eContextK8s121, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(c.Command).To(ContainElement("--cloud-provider=external"))
+			Expect(c.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true"))
+			Expect(c.Command).To(Not(test.ContainElementWithPrefixContaining("--external-cloud-volume-plugin=", "azure", ",")))
+		})
+
+		It("should keep the in-tree volume plugin of kube-controller-manager deployment until the nodes are migrated (k8s >= 1.21)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeControllerManager},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-controller-manager",
+										Command: []string{"--external-cloud-volume-plugin=azure"},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(&extensionsv1alpha1.Cluster{}))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err = ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), eContextK8s121, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(c.Command).To(ContainElement("--cloud-provider=external"))
+			Expect(c.Command).To(ContainElement("--external-cloud-volume-plugin=azure"))
+			Expect(c.Command).To(Not(test.ContainElementWithPrefixContaining("--feature-gates=", "CSIMigration=true", ",")))
+		})
+
 		It("should modify existing elements of kube-controller-manager deployment", func() {
 			var (
 				dep = &appsv1.Deployment{
@@ -316,10 +780,10 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -330,6 +794,52 @@ var _ = Describe("Ensurer", func() {
 		})
 	})
 
+	Describe("#EnsureKubeSchedulerDeployment", func() {
+		var dep *appsv1.Deployment
+
+		BeforeEach(func() {
+			dep = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeScheduler},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: "kube-scheduler",
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should not modify kube-scheduler deployment (k8s < 1.21)", func() {
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			err := ensurer.EnsureKubeSchedulerDeployment(context.TODO(), eContextK8s117, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-scheduler")
+			Expect(c.Command).To(BeEmpty())
+		})
+
+		It("should add the CSI migration feature gates to kube-scheduler deployment once the nodes are migrated (k8s >= 1.21)", func() {
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(migratedCluster))
+
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeSchedulerDeployment(context.TODO(), eContextK8s121, dep)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-scheduler")
+			Expect(c.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true"))
+		})
+	})
+
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
 		It("should modify existing elements of kubelet.service unit options", func() {
 			var (
@@ -354,10 +864,94 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 
 			// Call EnsureKubeletServiceUnitOptions method and check the result
-			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), dummyContext, oldUnitOptions)
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), eContextK8s117, oldUnitOptions)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(opts).To(Equal(newUnitOptions))
+		})
+
+		It("should add the image credential provider flags to kubelet.service unit options", func() {
+			var (
+				oldUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet`,
+					},
+				}
+				newUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=azure \
+    --cloud-config=/var/lib/kubelet/cloudprovider.conf \
+    --image-credential-provider-config=/var/lib/kubelet/credential-provider-config.yaml \
+    --image-credential-provider-bin-dir=/opt/bin/acr-credential-provider/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef`,
+					},
+					{
+						Section: "Unit",
+						Name:    "Requires",
+						Value:   "acr-credential-provider-installer.service",
+					},
+					{
+						Section: "Unit",
+						Name:    "After",
+						Value:   "acr-credential-provider-installer.service",
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), eContextK8s120ACR, oldUnitOptions)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(opts).To(Equal(newUnitOptions))
+		})
+
+		It("should fail if the image credential provider is not configured for the landscape", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(nil, imageVector, logger)
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			_, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), eContextK8s120ACR, []*unit.UnitOption{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should run the kubelet with the external cloud provider (k8s >= 1.21)", func() {
+			var (
+				oldUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=azure \
+    --cloud-config=/var/lib/kubelet/cloudprovider.conf`,
+					},
+				}
+				newUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=external`,
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), eContextK8s121, oldUnitOptions)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(opts).To(Equal(newUnitOptions))
 		})
@@ -381,14 +975,138 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := *oldKubeletConfig
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s117, &kubeletConfig)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
+		})
+
+		It("should enable the CSI migration feature gates in kubelet configuration (k8s >= 1.21)", func() {
+			var (
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo": true,
+					},
+				}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo":                   true,
+						"CSIMigration":          true,
+						"CSIMigrationAzureDisk": true,
+						"CSIMigrationAzureFile": true,
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), dummyContext, &kubeletConfig)
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s121, &kubeletConfig)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
+
+		It("should enable the image credential providers in kubelet configuration", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s120ACR, kubeletConfig)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(HaveKeyWithValue("KubeletCredentialProviders", true))
+		})
+
+		It("should not set the removed image credential provider feature gate in kubelet configuration (k8s >= 1.28)", func() {
+			cluster, err := eContextK8s120ACR.GetCluster(context.TODO())
+			Expect(err).To(Not(HaveOccurred()))
+			shoot := cluster.Shoot.DeepCopy()
+			shoot.Spec.Kubernetes.Version = "1.28.0"
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+			err = ensurer.EnsureKubeletConfiguration(context.TODO(), genericmutator.NewInternalEnsurerContext(&extensionscontroller.Cluster{Shoot: shoot}), kubeletConfig)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).NotTo(HaveKey("KubeletCredentialProviders"))
+		})
+	})
+
+	Describe("#EnsureAdditionalFiles", func() {
+		It("should not add files if no image credential provider is configured", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureAdditionalFiles method and check the result
+			files := []extensionsv1alpha1.File{}
+			err := ensurer.EnsureAdditionalFiles(context.TODO(), eContextK8s121, &files)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(files).To(BeEmpty())
+		})
+
+		It("should add the image credential provider configuration", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureAdditionalFiles method and check the result
+			files := []extensionsv1alpha1.File{}
+			err := ensurer.EnsureAdditionalFiles(context.TODO(), eContextK8s120ACR, &files)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(files).To(HaveLen(2))
+
+			Expect(files[0].Path).To(Equal("/var/lib/kubelet/acr-credential-provider.conf"))
+			Expect(files[0].Content.Inline.Data).To(Equal("useManagedIdentityExtension: true\nuserAssignedIdentityID: 00000000-1111-2222-3333-444444444444\n"))
+
+			Expect(files[1].Path).To(Equal("/var/lib/kubelet/credential-provider-config.yaml"))
+			Expect(files[1].Content.Inline.Data).To(MatchYAML(`apiVersion: kubelet.config.k8s.io/v1alpha1
+kind: CredentialProviderConfig
+providers:
+- name: acr-credential-provider
+  apiVersion: credentialprovider.kubelet.k8s.io/v1alpha1
+  matchImages:
+  - myregistry.azurecr.io
+  defaultCacheDuration: 10m
+  args:
+  - /var/lib/kubelet/acr-credential-provider.conf
+`))
+		})
+	})
+
+	Describe("#EnsureAdditionalUnits", func() {
+		It("should add the installer of the image credential provider", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
+
+			// Call EnsureAdditionalUnits method and check the result
+			units := []extensionsv1alpha1.Unit{}
+			err := ensurer.EnsureAdditionalUnits(context.TODO(), eContextK8s120ACR, &units)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(units).To(HaveLen(1))
+			Expect(units[0].Name).To(Equal("acr-credential-provider-installer.service"))
+			Expect(*units[0].Content).To(ContainSubstring("After=network-online.target"))
+			Expect(*units[0].Content).To(ContainSubstring(`ExecStart=/bin/sh -c "until test -x /opt/bin/acr-credential-provider/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef/acr-credential-provider || (`))
+			Expect(*units[0].Content).To(ContainSubstring(`; do sleep 10; done"`))
+			Expect(*units[0].Content).NotTo(ContainSubstring("Restart="))
+			Expect(*units[0].Content).To(ContainSubstring("-o /opt/bin/acr-credential-provider/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef/acr-credential-provider.tmp 'https://example.com/azure-acr-credential-provider-linux-amd64'"))
+			Expect(*units[0].Content).To(ContainSubstring("echo '0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef  /opt/bin/acr-credential-provider/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef/acr-credential-provider.tmp' | sha256sum -c -"))
+		})
+
+		It("should fail if the image credential provider is not configured for the landscape", func() {
+			// Create ensurer
+			ensurer := NewEnsurer(nil, imageVector, logger)
+
+			// Call EnsureAdditionalUnits method and check the result
+			units := []extensionsv1alpha1.Unit{}
+			err := ensurer.EnsureAdditionalUnits(context.TODO(), eContextK8s120ACR, &units)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("#EnsureKubeletCloudProviderConfig", func() {
@@ -408,7 +1126,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, cm.Name))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -423,7 +1141,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -438,7 +1156,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -501,6 +1219,8 @@ func clientGet(result runtime.Object) interface{} {
 			*obj.(*corev1.Secret) = *result.(*corev1.Secret)
 		case *corev1.ConfigMap:
 			*obj.(*corev1.ConfigMap) = *result.(*corev1.ConfigMap)
+		case *extensionsv1alpha1.Cluster:
+			*obj.(*extensionsv1alpha1.Cluster) = *result.(*extensionsv1alpha1.Cluster)
 		}
 		return nil
 	}
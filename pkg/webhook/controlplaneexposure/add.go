@@ -37,6 +37,10 @@ var (
 type AddOptions struct {
 	// ETCDStorage is the etcd storage configuration.
 	ETCDStorage config.ETCDStorage
+	// KubeAPIServerExposure is the default exposure configuration of the kube-apiservers.
+	KubeAPIServerExposure config.KubeAPIServerExposure
+	// KubeAPIServerService is the configuration of the load balancers of the kube-apiservers.
+	KubeAPIServerService config.KubeAPIServerService
 }
 
 var logger = log.Log.WithName("azure-controlplaneexposure-webhook")
@@ -48,7 +52,7 @@ func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) (*extensionsw
 		Kind:     controlplane.KindSeed,
 		Provider: azure.Type,
 		Types:    []runtime.Object{&appsv1.Deployment{}, &corev1.Service{}, &appsv1.StatefulSet{}},
-		Mutator:  genericmutator.NewMutator(NewEnsurer(&opts.ETCDStorage, logger), nil, nil, nil, logger),
+		Mutator:  genericmutator.NewMutator(NewEnsurer(&opts.ETCDStorage, &opts.KubeAPIServerExposure, &opts.KubeAPIServerService, logger), nil, nil, nil, logger),
 	})
 }
 
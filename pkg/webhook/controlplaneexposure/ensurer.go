@@ -16,8 +16,13 @@ package controlplaneexposure
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
@@ -32,19 +37,54 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// annotationIdleTimeout is the TCP idle timeout in minutes of the load balancer of a service.
+	annotationIdleTimeout = "service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout"
+	// annotationPublicIPName is the name of the static public IP address of the load balancer of a service.
+	annotationPublicIPName = "service.beta.kubernetes.io/azure-pip-name"
+	// annotationDNSLabelName is the DNS label of the public IP address of the load balancer of a service.
+	annotationDNSLabelName = "service.beta.kubernetes.io/azure-dns-label-name"
+	// annotationResourceGroup is the resource group of the public IP address of the load balancer of a service.
+	annotationResourceGroup = "service.beta.kubernetes.io/azure-load-balancer-resource-group"
+
+	// placeholderShootNamespace is replaced by the namespace of the shoot in the configured annotations.
+	placeholderShootNamespace = "$(SHOOT_NAMESPACE)"
+
+	// annotationInternalLoadBalancer makes the cloud provider expose a service on an internal load balancer.
+	annotationInternalLoadBalancer = "service.beta.kubernetes.io/azure-load-balancer-internal"
+	// annotationInternalLoadBalancerSubnet is the subnet in which the internal load balancer of a service is created.
+	annotationInternalLoadBalancerSubnet = "service.beta.kubernetes.io/azure-load-balancer-internal-subnet"
+
+	// annotationPrivateLinkServiceCreate makes the cloud provider create a Private Link Service for the internal load
+	// balancer of a service.
+	annotationPrivateLinkServiceCreate = "service.beta.kubernetes.io/azure-pls-create"
+	// annotationPrivateLinkServiceName is the name of the Private Link Service of a service.
+	annotationPrivateLinkServiceName = "service.beta.kubernetes.io/azure-pls-name"
+	// annotationPrivateLinkServiceVisibility is the space separated list of subscriptions to which the Private Link
+	// Service of a service is visible.
+	annotationPrivateLinkServiceVisibility = "service.beta.kubernetes.io/azure-pls-visibility"
+	// annotationPrivateLinkServiceAutoApproval is the space separated list of subscriptions whose connections to the
+	// Private Link Service of a service are approved automatically.
+	annotationPrivateLinkServiceAutoApproval = "service.beta.kubernetes.io/azure-pls-auto-approval"
+)
+
 // NewEnsurer creates a new controlplaneexposure ensurer.
-func NewEnsurer(etcdStorage *config.ETCDStorage, logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(etcdStorage *config.ETCDStorage, kubeAPIServerExposure *config.KubeAPIServerExposure, kubeAPIServerService *config.KubeAPIServerService, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		etcdStorage: etcdStorage,
-		logger:      logger.WithName("ensurer"),
+		etcdStorage:           etcdStorage,
+		kubeAPIServerExposure: kubeAPIServerExposure,
+		kubeAPIServerService:  kubeAPIServerService,
+		logger:                logger.WithName("ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	etcdStorage *config.ETCDStorage
-	client      client.Client
-	logger      logr.Logger
+	etcdStorage           *config.ETCDStorage
+	kubeAPIServerExposure *config.KubeAPIServerExposure
+	kubeAPIServerService  *config.KubeAPIServerService
+	client                client.Client
+	logger                logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -59,10 +99,97 @@ func (e *ensurer) EnsureKubeAPIServerService(ctx context.Context, ectx genericmu
 	if svc.Annotations == nil {
 		svc.Annotations = make(map[string]string)
 	}
-	svc.Annotations["service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout"] = "30"
+	e.ensureConfiguredAnnotations(svc)
+
+	cluster, err := controller.GetCluster(ctx, e.client, svc.Namespace)
+	if err != nil {
+		return err
+	}
+
+	cpConfig, err := helper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	if !e.isKubeAPIServerPrivate(cpConfig) {
+		delete(svc.Annotations, annotationInternalLoadBalancer)
+		delete(svc.Annotations, annotationInternalLoadBalancerSubnet)
+	} else {
+		svc.Annotations[annotationInternalLoadBalancer] = "true"
+		if subnet := e.kubeAPIServerExposure.InternalLoadBalancerSubnet; subnet != nil {
+			svc.Annotations[annotationInternalLoadBalancerSubnet] = *subnet
+		} else {
+			delete(svc.Annotations, annotationInternalLoadBalancerSubnet)
+		}
+	}
+
+	ensurePrivateLinkServiceAnnotations(svc, cpConfig.PrivateLinkService)
 	return nil
 }
 
+// ensureConfiguredAnnotations adds the annotations configured for the kube-apiserver services of the seed to the given
+// service.
+func (e *ensurer) ensureConfiguredAnnotations(svc *corev1.Service) {
+	serviceConfig := e.kubeAPIServerService
+	if serviceConfig == nil {
+		serviceConfig = &config.KubeAPIServerService{}
+	}
+
+	for key, value := range serviceConfig.Annotations {
+		svc.Annotations[key] = strings.ReplaceAll(value, placeholderShootNamespace, svc.Namespace)
+	}
+
+	if serviceConfig.IdleTimeoutInMinutes != nil {
+		svc.Annotations[annotationIdleTimeout] = strconv.Itoa(int(*serviceConfig.IdleTimeoutInMinutes))
+	} else if _, ok := serviceConfig.Annotations[annotationIdleTimeout]; !ok {
+		svc.Annotations[annotationIdleTimeout] = "30"
+	}
+
+	for annotation, value := range map[string]*string{
+		annotationPublicIPName:  serviceConfig.PublicIPName,
+		annotationDNSLabelName:  serviceConfig.DNSLabelName,
+		annotationResourceGroup: serviceConfig.ResourceGroup,
+	} {
+		if value != nil {
+			svc.Annotations[annotation] = strings.ReplaceAll(*value, placeholderShootNamespace, svc.Namespace)
+		}
+	}
+}
+
+// isKubeAPIServerPrivate returns true if the kube-apiserver is exposed on an internal load balancer. The setting of the
+// shoot takes precedence over the default of the seed, a private link service always requires an internal load balancer.
+func (e *ensurer) isKubeAPIServerPrivate(cpConfig *apisazure.ControlPlaneConfig) bool {
+	if exposure := cpConfig.KubeAPIServerExposure; exposure != nil {
+		return *exposure == apisazure.KubeAPIServerExposurePrivate
+	}
+	return e.kubeAPIServerExposure.Private || cpConfig.PrivateLinkService != nil
+}
+
+// ensurePrivateLinkServiceAnnotations adds the annotations for the private link service of the kube-apiserver to the
+// given service or removes them if no private link service is configured.
+func ensurePrivateLinkServiceAnnotations(svc *corev1.Service, privateLinkService *apisazure.PrivateLinkServiceConfig) {
+	if privateLinkService == nil {
+		for _, annotation := range []string{annotationPrivateLinkServiceCreate, annotationPrivateLinkServiceName, annotationPrivateLinkServiceVisibility, annotationPrivateLinkServiceAutoApproval} {
+			delete(svc.Annotations, annotation)
+		}
+		return
+	}
+
+	svc.Annotations[annotationPrivateLinkServiceCreate] = "true"
+	svc.Annotations[annotationPrivateLinkServiceName] = azure.KubeAPIServerPrivateLinkServiceName(svc.Namespace)
+
+	if len(privateLinkService.Visibility) > 0 {
+		svc.Annotations[annotationPrivateLinkServiceVisibility] = strings.Join(privateLinkService.Visibility, " ")
+	} else {
+		delete(svc.Annotations, annotationPrivateLinkServiceVisibility)
+	}
+	if len(privateLinkService.AutoApproval) > 0 {
+		svc.Annotations[annotationPrivateLinkServiceAutoApproval] = strings.Join(privateLinkService.AutoApproval, " ")
+	} else {
+		delete(svc.Annotations, annotationPrivateLinkServiceAutoApproval)
+	}
+}
+
 // EnsureKubeAPIServerDeployment ensures that the kube-apiserver deployment conforms to the provider requirements.
 func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, ectx genericmutator.EnsurerContext, dep *appsv1.Deployment) error {
 	cluster, err := controller.GetCluster(ctx, e.client, dep.Namespace)
@@ -74,7 +201,8 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, ectx generi
 		return nil
 	}
 
-	// Get load balancer address of the kube-apiserver service
+	// Get load balancer address of the kube-apiserver service, it is the private IP address of the internal load balancer
+	// if the kube-apiserver is not exposed publicly
 	address, err := kutil.GetLoadBalancerIngress(ctx, e.client, dep.Namespace, v1beta1constants.DeploymentNameKubeAPIServer)
 	if err != nil {
 		return errors.Wrap(err, "could not get kube-apiserver service load balancer address")
@@ -22,6 +22,8 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azurev1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -59,6 +61,8 @@ var _ = Describe("Ensurer", func() {
 			ClassName: util.StringPtr("gardener.cloud-fast"),
 			Capacity:  util.QuantityPtr(resource.MustParse("25Gi")),
 		}
+		kubeAPIServerExposure = &config.KubeAPIServerExposure{}
+		kubeAPIServerService  = &config.KubeAPIServerService{}
 
 		ctrl *gomock.Controller
 
@@ -90,6 +94,171 @@ var _ = Describe("Ensurer", func() {
 		ctrl.Finish()
 	})
 
+	Describe("#EnsureKubeAPIServerService", func() {
+		var (
+			private = apisazure.KubeAPIServerExposurePrivate
+			public  = apisazure.KubeAPIServerExposurePublic
+
+			clusterWithConfig = func(cpConfig *azurev1alpha1.ControlPlaneConfig) *extensionsv1alpha1.Cluster {
+				cpConfig.TypeMeta = metav1.TypeMeta{
+					APIVersion: azurev1alpha1.SchemeGroupVersion.String(),
+					Kind:       "ControlPlaneConfig",
+				}
+				return &extensionsv1alpha1.Cluster{
+					Spec: extensionsv1alpha1.ClusterSpec{
+						Shoot: runtime.RawExtension{
+							Raw: encode(&gardencorev1beta1.Shoot{
+								TypeMeta: metav1.TypeMeta{
+									APIVersion: gardencorev1beta1.SchemeGroupVersion.String(),
+									Kind:       "Shoot",
+								},
+								Spec: gardencorev1beta1.ShootSpec{
+									Provider: gardencorev1beta1.Provider{
+										ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+											RawExtension: runtime.RawExtension{
+												Raw: encode(cpConfig),
+											},
+										},
+									},
+								},
+							}),
+						},
+					},
+				}
+			}
+
+			clusterWithExposure = func(exposure *apisazure.KubeAPIServerExposure) *extensionsv1alpha1.Cluster {
+				return clusterWithConfig(&azurev1alpha1.ControlPlaneConfig{KubeAPIServerExposure: (*azurev1alpha1.KubeAPIServerExposure)(exposure)})
+			}
+
+			ensureService = func(exposure *config.KubeAPIServerExposure, cluster *extensionsv1alpha1.Cluster, svc *corev1.Service) {
+				c := mockclient.NewMockClient(ctrl)
+				c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+
+				ensurer := NewEnsurer(etcdStorage, exposure, kubeAPIServerService, logger)
+				err := ensurer.(inject.Client).InjectClient(c)
+				Expect(err).To(Not(HaveOccurred()))
+
+				err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc)
+				Expect(err).To(Not(HaveOccurred()))
+			}
+		)
+
+		It("should expose the kube-apiserver on a public load balancer by default", func() {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace}}
+
+			ensureService(kubeAPIServerExposure, cluster, svc)
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+			}))
+		})
+
+		It("should add the annotations configured for the seed", func() {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace}}
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, &config.KubeAPIServerService{
+				IdleTimeoutInMinutes: util.Int32Ptr(4),
+				PublicIPName:         util.StringPtr("$(SHOOT_NAMESPACE)-kube-apiserver"),
+				DNSLabelName:         util.StringPtr("api-$(SHOOT_NAMESPACE)"),
+				ResourceGroup:        util.StringPtr("seed-ips"),
+				Annotations: map[string]string{
+					"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout":      "10",
+					"service.beta.kubernetes.io/azure-load-balancer-health-probe-protocol": "tcp",
+				},
+			}, logger)
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout":      "4",
+				"service.beta.kubernetes.io/azure-pip-name":                            namespace + "-kube-apiserver",
+				"service.beta.kubernetes.io/azure-dns-label-name":                      "api-" + namespace,
+				"service.beta.kubernetes.io/azure-load-balancer-resource-group":        "seed-ips",
+				"service.beta.kubernetes.io/azure-load-balancer-health-probe-protocol": "tcp",
+			}))
+		})
+
+		It("should expose the kube-apiserver on an internal load balancer if the seed configures it", func() {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace}}
+
+			ensureService(&config.KubeAPIServerExposure{Private: true, InternalLoadBalancerSubnet: util.StringPtr("internal-lbs")}, cluster, svc)
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+				"service.beta.kubernetes.io/azure-load-balancer-internal":         "true",
+				"service.beta.kubernetes.io/azure-load-balancer-internal-subnet":  "internal-lbs",
+			}))
+		})
+
+		It("should expose the kube-apiserver on an internal load balancer if the shoot configures it", func() {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace}}
+
+			ensureService(kubeAPIServerExposure, clusterWithExposure(&private), svc)
+			Expect(svc.Annotations).To(HaveKeyWithValue("service.beta.kubernetes.io/azure-load-balancer-internal", "true"))
+			Expect(svc.Annotations).NotTo(HaveKey("service.beta.kubernetes.io/azure-load-balancer-internal-subnet"))
+		})
+
+		It("should remove the internal load balancer annotations if the shoot is exposed publicly", func() {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      v1beta1constants.DeploymentNameKubeAPIServer,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/azure-load-balancer-internal":        "true",
+						"service.beta.kubernetes.io/azure-load-balancer-internal-subnet": "internal-lbs",
+					},
+				},
+			}
+
+			ensureService(&config.KubeAPIServerExposure{Private: true}, clusterWithExposure(&public), svc)
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+			}))
+		})
+
+		It("should create a private link service on an internal load balancer if the shoot configures it", func() {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace}}
+
+			ensureService(kubeAPIServerExposure, clusterWithConfig(&azurev1alpha1.ControlPlaneConfig{
+				PrivateLinkService: &azurev1alpha1.PrivateLinkServiceConfig{
+					Visibility:   []string{"sub-1", "sub-2"},
+					AutoApproval: []string{"sub-2"},
+				},
+			}), svc)
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+				"service.beta.kubernetes.io/azure-load-balancer-internal":         "true",
+				"service.beta.kubernetes.io/azure-pls-create":                     "true",
+				"service.beta.kubernetes.io/azure-pls-name":                       namespace + "-kube-apiserver",
+				"service.beta.kubernetes.io/azure-pls-visibility":                 "sub-1 sub-2",
+				"service.beta.kubernetes.io/azure-pls-auto-approval":              "sub-2",
+			}))
+		})
+
+		It("should remove the private link service annotations if the shoot does not configure it", func() {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      v1beta1constants.DeploymentNameKubeAPIServer,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/azure-pls-create":     "true",
+						"service.beta.kubernetes.io/azure-pls-name":       namespace + "-kube-apiserver",
+						"service.beta.kubernetes.io/azure-pls-visibility": "*",
+					},
+				},
+			}
+
+			ensureService(kubeAPIServerExposure, cluster, svc)
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+			}))
+		})
+	})
+
 	Describe("#EnsureKubeAPIServerDeployment", func() {
 		It("should add missing elements to kube-apiserver deployment", func() {
 			var (
@@ -115,7 +284,7 @@ var _ = Describe("Ensurer", func() {
 			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 			err := ensurer.(inject.Client).InjectClient(c)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -150,7 +319,7 @@ var _ = Describe("Ensurer", func() {
 			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 			err := ensurer.(inject.Client).InjectClient(c)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -170,7 +339,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 
 			// Call EnsureETCDStatefulSet method and check the result
 			err := ensurer.EnsureETCDStatefulSet(context.TODO(), dummyContext, ss)
@@ -201,7 +370,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 
 			// Call EnsureETCDStatefulSet method and check the result
 			err := ensurer.EnsureETCDStatefulSet(context.TODO(), dummyContext, ss)
@@ -217,7 +386,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 
 			// Call EnsureETCDStatefulSet method and check the result
 			err := ensurer.EnsureETCDStatefulSet(context.TODO(), dummyContext, ss)
@@ -248,7 +417,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, kubeAPIServerService, logger)
 
 			// Call EnsureETCDStatefulSet method and check the result
 			err := ensurer.EnsureETCDStatefulSet(context.TODO(), dummyContext, ss)